@@ -0,0 +1,68 @@
+package v
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteUnits 字节大小的单位，十进制单位（KB、MB）以 1000 进位，二进制单位（KiB、MiB）以 1024 进位
+var byteUnits = map[string]float64{
+	"":    1,
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"PB":  1e15,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+	"PIB": 1 << 50,
+}
+
+// parseByteSize 解析字节大小，如：1024、10MB、1.5GiB，单位不区分大小写，数值与单位之间允许有空格
+func parseByteSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(s)
+	}
+	unit, ok := byteUnits[strings.ToUpper(strings.TrimSpace(s[i:]))]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size %q: unknown unit", s)
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", s)
+	}
+	size := n * unit
+	if size > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid byte size %q: out of range", s)
+	}
+	return int64(size), nil
+}
+
+// mustParseByteSize 解析字节大小，失败时引发 panic
+func mustParseByteSize(s string) int64 {
+	size, err := parseByteSize(s)
+	if err != nil {
+		panic(err)
+	}
+	return size
+}
+
+// toByteSize 将整数值（字节数）或字节大小字符串转换成字节数
+func toByteSize(val any) (int64, bool) {
+	if s, ok := val.(string); ok {
+		size, err := parseByteSize(s)
+		return size, err == nil
+	}
+	f, ok := toFloat(val)
+	if !ok || f < 0 || f != math.Trunc(f) || f > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
@@ -0,0 +1,77 @@
+package v
+
+import (
+	"context"
+	"sync"
+)
+
+// Context 验证上下文，用于在多个验证器及其规则之间共享数据，
+// 如：读取其它字段的值、保存可供后续规则复用的中间结果（解析后的时间等）。
+// 上下文通常只在一次验证过程中使用，可以被多个协程安全地并发访问
+type Context struct {
+	mu     sync.RWMutex
+	fields map[string]any // 已绑定的验证器的字段值
+	values map[string]any // 规则之间共享的数据
+}
+
+// NewContext 创建验证上下文
+func NewContext() *Context {
+	return &Context{
+		fields: map[string]any{},
+		values: map[string]any{},
+	}
+}
+
+// Field 返回绑定到上下文的验证器的字段值
+func (c *Context) Field(field string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.fields[field]
+	return value, ok
+}
+
+// Get 返回共享数据
+func (c *Context) Get(key string) (any, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	value, ok := c.values[key]
+	return value, ok
+}
+
+// Set 设置共享数据
+func (c *Context) Set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] = value
+}
+
+func (c *Context) setField(field string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fields[field] = value
+}
+
+type contextKey struct{}
+
+// ContextFrom 返回 context.Context 中携带的验证上下文，
+// 绑定了验证上下文的验证器在执行 ValidateContext 时会将其传递给每条规则
+func ContextFrom(ctx context.Context) *Context {
+	c, _ := ctx.Value(contextKey{}).(*Context)
+	return c
+}
+
+// contextual 可以绑定验证上下文的验证器
+type contextual interface {
+	bind(c *Context)
+}
+
+// ValidateWith 将验证上下文绑定到各个验证器后执行验证，
+// 所有验证器的字段值在验证开始前就已写入上下文，因此规则可以读取任意字段的值
+func ValidateWith(c *Context, validations ...Validatable) error {
+	for _, validation := range validations {
+		if x, ok := validation.(contextual); ok {
+			x.bind(c)
+		}
+	}
+	return Validate(validations...)
+}
@@ -0,0 +1,91 @@
+package v
+
+import (
+	"strconv"
+	"strings"
+)
+
+// 信用卡网络
+const (
+	CardVisa       = "visa"
+	CardMasterCard = "mastercard"
+	CardAmex       = "amex"
+	CardDiscover   = "discover"
+)
+
+// cardNetwork 信用卡网络的发卡行识别码（IIN）范围及卡号长度
+type cardNetwork struct {
+	ranges  [][2]int // 卡号前缀范围（闭区间）
+	lengths []int    // 允许的卡号长度
+}
+
+var cardNetworks = map[string]cardNetwork{
+	CardVisa:       {ranges: [][2]int{{4, 4}}, lengths: []int{13, 16, 19}},
+	CardMasterCard: {ranges: [][2]int{{51, 55}, {2221, 2720}}, lengths: []int{16}},
+	CardAmex:       {ranges: [][2]int{{34, 34}, {37, 37}}, lengths: []int{15}},
+	CardDiscover:   {ranges: [][2]int{{6011, 6011}, {644, 649}, {65, 65}, {622126, 622925}}, lengths: []int{16, 17, 18, 19}},
+}
+
+// normalizeCardNumber 移除卡号中的空格和短横线
+func normalizeCardNumber(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// isCreditCard 判断卡号（已移除分隔符）是否为有效的信用卡号
+func isCreditCard(number string) bool {
+	if len(number) < 12 || len(number) > 19 {
+		return false
+	}
+	return luhn(number)
+}
+
+// luhn 使用 Luhn 算法校验数字字符串
+func luhn(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		n := int(c - '0')
+		if double {
+			n *= 2
+			if n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// cardNetworkOf 判断卡号是否属于指定的信用卡网络
+func cardNetworkOf(number string, name string) bool {
+	network, ok := cardNetworks[strings.ToLower(name)]
+	if !ok {
+		return false
+	}
+	valid := false
+	for _, n := range network.lengths {
+		if len(number) == n {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return false
+	}
+	for _, r := range network.ranges {
+		digits := len(strconv.Itoa(r[0]))
+		if len(number) < digits {
+			continue
+		}
+		prefix, err := strconv.Atoi(number[:digits])
+		if err == nil && prefix >= r[0] && prefix <= r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,22 @@
 package v
 
 import (
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
 	"strings"
 )
 
 var emptyErrors []*Error
 
+// 脱敏后的值的显示内容
+const redactedValue = "***"
+
+// redactedParams 携带值的错误参数，脱敏时与值一同被替换为 "***"
+var redactedParams = []string{"another", "expected", "actual", "old", "new"}
+
 func init() {
 	emptyErrors = make([]*Error, 0)
 }
@@ -20,8 +30,18 @@ type Error struct {
 	field  string
 	label  string
 	value  any
+	redact bool // 是否对值进行脱敏处理
+	level  Severity
 }
 
+// Severity 错误的严重级别
+type Severity int
+
+const (
+	SeverityError   Severity = iota // 阻断性错误（默认）
+	SeverityWarning                 // 警告，不影响请求的处理
+)
+
 // ErrorOption 错误配置函数签名
 type ErrorOption func(*Error)
 
@@ -38,6 +58,14 @@ func NewError(code string, options ...ErrorOption) *Error {
 	return &e
 }
 
+// NewFieldError 创建指定字段的错误实例，用于在验证器之外产生字段相关的错误
+func NewFieldError(field, label, code string, options ...ErrorOption) *Error {
+	e := NewError(code, options...)
+	e.field = field
+	e.label = label
+	return e
+}
+
 // ErrorFormat 设置错误格式化字符串
 func ErrorFormat(format string) ErrorOption {
 	return func(e *Error) {
@@ -62,6 +90,29 @@ func ErrorCode(code string) ErrorOption {
 	}
 }
 
+// ErrorRedact 对错误中的值进行脱敏处理，适用于密码、令牌等敏感字段
+func ErrorRedact() ErrorOption {
+	return func(e *Error) {
+		e.redact = true
+	}
+}
+
+// ErrorSeverity 设置错误的严重级别
+func ErrorSeverity(level Severity) ErrorOption {
+	return func(e *Error) {
+		e.level = level
+	}
+}
+
+// clone 返回错误实例的副本
+func (e *Error) clone() *Error {
+	c := *e
+	if e.params != nil {
+		c.params = maps.Clone(e.params)
+	}
+	return &c
+}
+
 // Code 返回错误代码
 func (e *Error) Code() string {
 	return e.code
@@ -72,7 +123,7 @@ func (e *Error) Format() string {
 	return e.format
 }
 
-// Params 返回错误格式化蚕食
+// Params 返回错误格式化蚕食，若已脱敏则携带值的参数（如：another、old）为 "***"
 func (e *Error) Params() map[string]any {
 	p := map[string]any{}
 	if e.params != nil {
@@ -80,6 +131,13 @@ func (e *Error) Params() map[string]any {
 			p[k] = v
 		}
 	}
+	if e.redact {
+		for _, k := range redactedParams {
+			if _, ok := p[k]; ok {
+				p[k] = redactedValue
+			}
+		}
+	}
 	return p
 }
 
@@ -88,25 +146,105 @@ func (e *Error) Field() string {
 	return e.field
 }
 
+// Path 返回字段的完整路径，集合元素的错误包含元素的位置，如：tags[3]、addresses.city，
+// 与 Field 相同
+func (e *Error) Path() string {
+	return e.field
+}
+
 // Label 返回错误标签
 func (e *Error) Label() string {
 	return e.label
 }
 
-// Value 返回用于验证的值
+// Value 返回用于验证的值，若已脱敏则返回 "***"
 func (e *Error) Value() any {
+	if e.redact {
+		return redactedValue
+	}
 	return e.value
 }
 
+// Severity 返回错误的严重级别
+func (e *Error) Severity() Severity {
+	return e.level
+}
+
+// Redacted 返回值是否已被脱敏
+func (e *Error) Redacted() bool {
+	return e.redact
+}
+
 // String 实现 fmt.Stringer 接口，返回格式化后的字符串
 func (e *Error) String() string {
+	return e.render("", DefaultTranslator())
+}
+
+// Localize 使用指定语言包格式化错误信息，
+// 若语言包未注册或其中未定义该错误代码的消息，则与 String 相同
+func (e *Error) Localize(locale string) string {
+	return e.localize(locale, DefaultTranslator())
+}
+
+// localize 使用指定语言包格式化错误信息，
+// 若语言包未注册或其中未定义该错误代码的消息，则使用 translator 格式化
+func (e *Error) localize(locale string, translator Translator) string {
+	if locale == "" {
+		return e.render(locale, translator)
+	}
+	l, found := lookupLocale(locale)
+	if !found {
+		return e.render(locale, translator)
+	}
 	message := e.format
+	if message == "" {
+		message = l.Messages[e.code]
+	}
+	if message == "" {
+		return e.render(locale, translator)
+	}
+	params := e.templateParams(locale, translator)
+	if kind, ok := params["kind"].(reflect.Kind); ok {
+		params["type"] = l.typeName(kind)
+	}
+	if l.Translator != nil {
+		return l.Translator(message, params)
+	}
+	return interpolate(message, params)
+}
+
+// templateParams 返回用于格式化错误信息的参数，
+// 参数中的嵌套错误集（如：some_of 的 errors）使用相同的语言包及翻译函数逐行格式化
+func (e *Error) templateParams(locale string, translator Translator) map[string]any {
 	params := e.Params()
 	params["label"] = e.label
-	params["value"] = e.value
+	params["value"] = e.Value()
 	//params["field"] = e.field
+	for key, value := range params {
+		if errs, ok := value.(*Errors); ok {
+			params[key] = errs.lines(locale, translator)
+		}
+	}
+	return params
+}
+
+// interpolate 使用参数替换消息模板中的占位符
+func interpolate(message string, params map[string]any) string {
+	for key, value := range params {
+		message = strings.ReplaceAll(message, "{"+key+"}", fmt.Sprintf("%v", value))
+	}
+	return message
+}
+
+// render 使用指定的默认翻译函数格式化错误信息，locale 仅用于格式化参数中的嵌套错误集
+func (e *Error) render(locale string, translator Translator) string {
+	message := e.format
+	params := e.templateParams(locale, translator)
+	if kind, ok := params["kind"].(reflect.Kind); ok {
+		params["type"] = typeName(kind)
+	}
 	// 定义了消息或翻译函数
-	if t, found := translations[e.code]; found {
+	if t, found := lookupTranslation(e.code); found {
 		if message == "" {
 			message = t.message
 		}
@@ -115,13 +253,59 @@ func (e *Error) String() string {
 		}
 	}
 	// 设置了默认翻译函数
-	if defaultTranslator != nil {
-		return defaultTranslator(message, params)
+	if translator != nil {
+		return translator(message, params)
 	}
-	for key, value := range params {
-		message = strings.ReplaceAll(message, "{"+key+"}", fmt.Sprintf("%v", value))
+	return interpolate(message, params)
+}
+
+// errorJSON 错误的 JSON 结构
+type errorJSON struct {
+	Field   string         `json:"field"`
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Params  map[string]any `json:"params,omitempty"`
+}
+
+// toJSON 使用指定的语言包及默认翻译函数生成错误的 JSON 结构，
+// 无法序列化的参数（如：reflect.Kind）将被转换成字符串
+func (e *Error) toJSON(locale string, translator Translator) errorJSON {
+	var params map[string]any
+	if len(e.params) > 0 {
+		params = make(map[string]any, len(e.params))
+		for key, value := range e.Params() {
+			params[key] = jsonValue(value)
+		}
 	}
-	return message
+	message := e.localize(locale, translator)
+	if e.error != nil {
+		message = e.error.Error()
+	}
+	return errorJSON{
+		Field:   e.field,
+		Code:    e.code,
+		Message: message,
+		Params:  params,
+	}
+}
+
+func jsonValue(value any) any {
+	switch x := value.(type) {
+	case nil, json.Marshaler:
+		return value
+	case fmt.Stringer:
+		return x.String()
+	}
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return value
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，
+// 格式为 {"field":"email","code":"is_email","message":"...","params":{...}}
+func (e *Error) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.toJSON("", DefaultTranslator()))
 }
 
 // Error 实现内置错误接口（优先使用内部错误）
@@ -134,7 +318,43 @@ func (e *Error) Error() string {
 
 // Errors 错误集
 type Errors struct {
-	errors []*Error
+	errors     []*Error
+	translator Translator
+	locale     string
+}
+
+// WithTranslator 设置错误集专用的翻译函数，未设置时使用全局默认翻译函数，
+// 适用于并发处理不同语言请求的场景
+func (e *Errors) WithTranslator(translator Translator) *Errors {
+	if e != nil {
+		e.translator = translator
+	}
+	return e
+}
+
+// WithLocale 设置错误集使用的语言包（通过 RegisterLocale 注册），
+// 语言包未注册或其中未定义错误代码的消息时，回退到错误集的翻译函数
+func (e *Errors) WithLocale(locale string) *Errors {
+	if e != nil {
+		e.locale = locale
+	}
+	return e
+}
+
+// Locale 返回错误集使用的语言包名称
+func (e *Errors) Locale() string {
+	if e == nil {
+		return ""
+	}
+	return e.locale
+}
+
+// Translator 返回错误集使用的翻译函数
+func (e *Errors) Translator() Translator {
+	if e != nil && e.translator != nil {
+		return e.translator
+	}
+	return DefaultTranslator()
 }
 
 // IsEmpty 是否存在错误
@@ -157,13 +377,86 @@ func (e *Errors) Add(err error) {
 		if !ex.IsEmpty() {
 			e.errors = append(e.errors, ex.errors...)
 		}
-	} else if ex, ok := err.(*Error); ok && ex != nil {
-		e.errors = append(e.errors, ex)
+	} else if ex, ok := err.(*Error); ok {
+		if ex != nil {
+			e.errors = append(e.errors, ex)
+		}
 	} else {
 		e.errors = append(e.errors, &Error{error: err})
 	}
 }
 
+// Merge 将另一个错误集中的错误合并到当前错误集，接收者或参数为 nil 时不做任何处理
+func (e *Errors) Merge(other *Errors) {
+	if e == nil || other.IsEmpty() {
+		return
+	}
+	e.errors = append(e.errors, other.errors...)
+}
+
+// Prefix 为错误集中每个错误的字段名添加前缀，如：city 变为 address.city，字段名为空的错误将使用前缀作为字段名，
+// 默认使用 . 连接，设置了 SetPathSeparator 时使用该分隔符，返回当前错误集以便与 Merge 配合使用
+func (e *Errors) Prefix(prefix string) *Errors {
+	if e.IsEmpty() || prefix == "" {
+		return e
+	}
+	sep := fieldSeparator()
+	for i, err := range e.errors {
+		// 错误可能通过 Merge 被其它错误集共享，修改其副本
+		err = err.clone()
+		if err.field == "" {
+			err.field = prefix
+		} else {
+			err.field = prefix + sep + err.field
+		}
+		e.errors[i] = err
+	}
+	return e
+}
+
+// Join 将多个错误合并成一个错误集，忽略其中的 nil 值，
+// 与 errors.Join 类似，若所有错误均为 nil 则返回 nil
+func Join(errs ...error) *Errors {
+	e := &Errors{}
+	for _, err := range errs {
+		e.Add(err)
+	}
+	if e.IsEmpty() {
+		return nil
+	}
+	return e
+}
+
+// Clone 返回错误集的独立副本，修改副本（包括其中的错误实例）不会影响原错误集，
+// 适用于将同一错误集交给多个协程读取或转换的场景
+func (e *Errors) Clone() *Errors {
+	if e == nil {
+		return nil
+	}
+	c := &Errors{translator: e.translator, locale: e.locale}
+	if e.errors != nil {
+		c.errors = make([]*Error, len(e.errors))
+		for i, err := range e.errors {
+			c.errors[i] = err.clone()
+		}
+	}
+	return c
+}
+
+// SuggestedStatus 返回建议的 HTTP 状态码，存在阻断性错误时返回 422，
+// 只有警告（参考 ErrorSeverity）或没有错误时返回 200
+func (e *Errors) SuggestedStatus() int {
+	if e.IsEmpty() {
+		return http.StatusOK
+	}
+	for _, err := range e.errors {
+		if err.level == SeverityError {
+			return http.StatusUnprocessableEntity
+		}
+	}
+	return http.StatusOK
+}
+
 // First 返回第一个错误实例，如果不存在则返回 nil
 func (e *Errors) First() *Error {
 	if e.IsEmpty() {
@@ -189,6 +482,50 @@ func (e *Errors) Get(field string) []*Error {
 	return nil
 }
 
+// GetByPrefix 获取路径 prefix 及其下级路径的错误列表，如：prefix 为 addresses 时，
+// 将匹配 addresses、addresses[0]、addresses[0].city 等路径，如果不存在将返回 nil
+func (e *Errors) GetByPrefix(prefix string) []*Error {
+	if e.IsEmpty() {
+		return nil
+	}
+	var errs []*Error
+	for _, err := range e.errors {
+		if isSubPath(err.field, prefix) {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// isSubPath 判断 path 是否为 prefix 本身或其下级路径
+func isSubPath(path, prefix string) bool {
+	if prefix == "" || path == prefix {
+		return true
+	}
+	rest, found := strings.CutPrefix(path, prefix)
+	if !found {
+		return false
+	}
+	if rest[0] == '.' || rest[0] == '[' {
+		return true
+	}
+	sep := getPathSeparator()
+	return sep != "" && strings.HasPrefix(rest, sep)
+}
+
+// Has 是否存在指定字段的错误
+func (e *Errors) Has(field string) bool {
+	if e.IsEmpty() {
+		return false
+	}
+	for _, err := range e.errors {
+		if err.field == field {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Errors) All() []*Error {
 	if e == nil {
 		return emptyErrors
@@ -196,6 +533,18 @@ func (e *Errors) All() []*Error {
 	return e.errors
 }
 
+// Errors 以 []error 的形式返回错误列表，便于与 errors.Join 等标准库函数配合使用
+func (e *Errors) Errors() []error {
+	if e.IsEmpty() {
+		return nil
+	}
+	errs := make([]error, len(e.errors))
+	for i, err := range e.errors {
+		errs[i] = err
+	}
+	return errs
+}
+
 // ToMap 根据错误标签分组
 func (e *Errors) ToMap() map[string][]*Error {
 	if e == nil || e.IsEmpty() {
@@ -217,6 +566,7 @@ func (e *Errors) String() string {
 		return ""
 	}
 
+	translator := e.Translator()
 	var errors []string
 	for _, errs := range errsMap {
 		buf := strings.Builder{}
@@ -224,13 +574,44 @@ func (e *Errors) String() string {
 			if i > 0 {
 				buf.WriteString("\n")
 			}
-			buf.WriteString(err.String())
+			buf.WriteString(err.localize(e.locale, translator))
 		}
 		errors = append(errors, buf.String())
 	}
 	return strings.Join(errors, "\n")
 }
 
+// MarshalJSON 实现 json.Marshaler 接口，格式为字段名到错误列表的映射，
+// 分组方式与 ToMap 相同，错误集为空时返回 {}
+func (e *Errors) MarshalJSON() ([]byte, error) {
+	translator := e.Translator()
+	errs := map[string][]errorJSON{}
+	for field, items := range e.ToMap() {
+		list := make([]errorJSON, len(items))
+		for i, err := range items {
+			list[i] = err.toJSON(e.Locale(), translator)
+		}
+		errs[field] = list
+	}
+	return json.Marshal(errs)
+}
+
+// lines 按添加顺序逐行格式化错误集中的错误，未通过验证器产生的普通错误使用其自身的错误信息
+func (e *Errors) lines(locale string, translator Translator) string {
+	if e.IsEmpty() {
+		return ""
+	}
+	lines := make([]string, len(e.errors))
+	for i, err := range e.errors {
+		if err.error != nil && err.code == "" {
+			lines[i] = err.error.Error()
+		} else {
+			lines[i] = err.localize(locale, translator)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (e *Errors) Error() string {
 	return e.String()
 }
@@ -0,0 +1,162 @@
+package v
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	var typedNil *Error
+	plain := errors.New("plain")
+	single := NewFieldError("email", "邮箱", "is_email")
+	multi := &Errors{}
+	multi.Add(NewFieldError("name", "姓名", "required"))
+	multi.Add(NewFieldError("age", "年龄", "between"))
+
+	if got := Join(); got != nil {
+		t.Errorf("Join() = %v, want nil", got)
+	}
+	if got := Join(nil, typedNil, (*Errors)(nil), &Errors{}); got != nil {
+		t.Errorf("Join(nils) = %v, want nil", got)
+	}
+
+	errs := Join(nil, single, typedNil, multi, plain)
+	if errs == nil {
+		t.Fatal("Join(mixed) = nil")
+	}
+	all := errs.All()
+	if len(all) != 4 {
+		t.Fatalf("len(Join(mixed)) = %d, want 4", len(all))
+	}
+	if all[0] != single {
+		t.Errorf("Join(mixed)[0] = %v, want the *Error itself", all[0])
+	}
+	if all[1].Field() != "name" || all[2].Field() != "age" {
+		t.Errorf("Join(mixed) did not flatten *Errors: %v, %v", all[1].Field(), all[2].Field())
+	}
+	if all[3].Error() != "plain" {
+		t.Errorf("Join(mixed)[3] = %v, want the plain error", all[3])
+	}
+	if got := errs.Get("age"); len(got) != 1 {
+		t.Errorf("Get(age) = %v, want one error", got)
+	}
+}
+
+// 使用 go test -race 运行，验证副本与原错误集之间不存在数据竞争
+func TestErrorsCloneConcurrent(t *testing.T) {
+	errs := Join(
+		NewFieldError("name", "姓名", "required"),
+		NewFieldError("age", "年龄", "between", ErrorParam("min", 18), ErrorParam("max", 60)),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := errs.Clone()
+			c.Add(NewFieldError("extra", "额外", "required"))
+			for _, e := range c.All() {
+				e.field = "user." + e.field
+				e.params = map[string]any{"i": i}
+			}
+			_ = c.String()
+			_ = errs.String()
+		}(i)
+	}
+	wg.Wait()
+
+	all := errs.All()
+	if len(all) != 2 {
+		t.Fatalf("len(original) = %d, want 2", len(all))
+	}
+	if all[0].Field() != "name" || all[1].Field() != "age" {
+		t.Errorf("original fields changed after cloning: %q, %q", all[0].Field(), all[1].Field())
+	}
+	if got := all[1].Params()["min"]; got != 18 {
+		t.Errorf("original params changed after cloning: %v", all[1].Params())
+	}
+}
+
+func TestSuggestedStatus(t *testing.T) {
+	warning := NewFieldError("bio", "简介", "max_length", ErrorSeverity(SeverityWarning))
+	blocking := NewFieldError("name", "姓名", "required")
+	tests := []struct {
+		name string
+		errs *Errors
+		want int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"empty", &Errors{}, http.StatusOK},
+		{"warnings only", Join(warning), http.StatusOK},
+		{"blocking", Join(blocking), http.StatusUnprocessableEntity},
+		{"mixed", Join(warning, blocking), http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		if got := tt.errs.SuggestedStatus(); got != tt.want {
+			t.Errorf("%s: SuggestedStatus() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestErrorsPrefix(t *testing.T) {
+	address := Join(NewFieldError("city", "城市", "required"), NewError("invalid"))
+	var home, work Errors
+	home.Merge(address)
+	work.Merge(address)
+	home.Prefix("home")
+	work.Prefix("work")
+
+	fields := func(errs *Errors) []string {
+		var fields []string
+		for _, err := range errs.All() {
+			fields = append(fields, err.Field())
+		}
+		return fields
+	}
+	tests := []struct {
+		errs *Errors
+		want []string
+	}{
+		{&home, []string{"home.city", "home"}},
+		{&work, []string{"work.city", "work"}},
+		{address, []string{"city", ""}},
+	}
+	for _, tt := range tests {
+		if got := fields(tt.errs); !slices.Equal(got, tt.want) {
+			t.Errorf("fields = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRedactedErrorJSON(t *testing.T) {
+	err := Value("hunter2", "confirm", "确认密码").RedactValue().Equal("s3cret").Validate()
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("Validate() = %#v, want *Error", err)
+	}
+	data, jsonErr := json.Marshal(e)
+	if jsonErr != nil {
+		t.Fatal(jsonErr)
+	}
+	for _, secret := range []string{"hunter2", "s3cret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("json = %s, want %q redacted", data, secret)
+		}
+		if strings.Contains(e.String(), secret) {
+			t.Errorf("String() = %q, want %q redacted", e.String(), secret)
+		}
+	}
+	if got := e.Params()["another"]; got != "***" {
+		t.Errorf(`Params()["another"] = %v, want ***`, got)
+	}
+	// 副本保留原始参数，脱敏只在输出时生效
+	if got := e.clone().params["another"]; got != "s3cret" {
+		t.Errorf("clone params another = %v, want s3cret", got)
+	}
+}
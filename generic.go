@@ -0,0 +1,91 @@
+package v
+
+import (
+	"cmp"
+
+	"zestack.dev/is"
+)
+
+// ordered 值的类型为 T 时直接比较，否则回退到基于反射的比较函数
+func ordered[T cmp.Ordered](check func(T) bool, fallback func(any) bool) func(any) bool {
+	return func(a any) bool {
+		if x, ok := a.(T); ok {
+			return check(x)
+		}
+		return fallback(a)
+	}
+}
+
+// GreaterThanG 泛型版本的 GreaterThan，值的类型为 T 时通过类型断言直接比较，无需反射（值仍以 any 的形式传递给规则）
+func GreaterThanG[T cmp.Ordered](v *Valuer, min T, options ...ErrorOption) *Valuer {
+	return v.simple(
+		"greater_than",
+		ordered(func(a T) bool { return a > min }, func(a any) bool { return is.GreaterThan(a, min) }),
+		merge(options, ErrorParam("min", min)),
+	)
+}
+
+// GreaterEqualThanG 泛型版本的 GreaterEqualThan
+func GreaterEqualThanG[T cmp.Ordered](v *Valuer, min T, options ...ErrorOption) *Valuer {
+	return v.simple(
+		"greater_equal_than",
+		ordered(func(a T) bool { return a >= min }, func(a any) bool { return is.GreaterEqualThan(a, min) }),
+		merge(options, ErrorParam("min", min)),
+	)
+}
+
+// LessThanG 泛型版本的 LessThan
+func LessThanG[T cmp.Ordered](v *Valuer, max T, options ...ErrorOption) *Valuer {
+	return v.simple(
+		"less_than",
+		ordered(func(a T) bool { return a < max }, func(a any) bool { return is.LessThan(a, max) }),
+		merge(options, ErrorParam("max", max)),
+	)
+}
+
+// LessEqualThanG 泛型版本的 LessEqualThan
+func LessEqualThanG[T cmp.Ordered](v *Valuer, max T, options ...ErrorOption) *Valuer {
+	return v.simple(
+		"less_equal_than",
+		ordered(func(a T) bool { return a <= max }, func(a any) bool { return is.LessEqualThan(a, max) }),
+		merge(options, ErrorParam("max", max)),
+	)
+}
+
+// BetweenG 泛型版本的 Between
+func BetweenG[T cmp.Ordered](v *Valuer, min, max T, options ...ErrorOption) *Valuer {
+	return v.simple(
+		"between",
+		ordered(func(a T) bool { return a >= min && a <= max }, func(a any) bool { return is.Between(a, min, max) }),
+		merge(options, ErrorParam("min", min), ErrorParam("max", max)),
+	)
+}
+
+// OneOfValues 将一组同类型的值（如：枚举常量）转换成 []any，便于传给 OneOf、NotOneOf 等规则使用
+func OneOfValues[T comparable](items ...T) []any {
+	values := make([]any, len(items))
+	for i, item := range items {
+		values[i] = item
+	}
+	return values
+}
+
+// OneOfG 泛型版本的 OneOf，值的类型为 T 时通过类型断言直接比较，无需反射
+func OneOfG[T comparable](v *Valuer, items []T, options ...ErrorOption) *Valuer {
+	values := OneOfValues(items...)
+	return v.simple(
+		"one_of",
+		func(a any) bool {
+			if x, ok := a.(T); ok {
+				for _, item := range items {
+					if x == item {
+						return true
+					}
+				}
+				return false
+			}
+			return is.OneOf(a, values)
+		},
+		merge(options, ErrorParam("items", values)),
+	)
+}
@@ -0,0 +1,32 @@
+package v
+
+import "testing"
+
+func TestGreaterThanG(t *testing.T) {
+	if err := GreaterThanG(Value(10, "age", "年龄"), 5).Validate(); err != nil {
+		t.Errorf("GreaterThanG(10, 5) = %v, want nil", err)
+	}
+	if code := codeOf(t, GreaterThanG(Value(3, "age", "年龄"), 5).Validate()); code != "greater_than" {
+		t.Errorf("GreaterThanG(3, 5) = %q, want greater_than", code)
+	}
+	if code := codeOf(t, BetweenG(Value(3.5, "score", "分数"), 4.0, 5.0).Validate()); code != "between" {
+		t.Errorf("BetweenG(3.5, 4, 5) = %q, want between", code)
+	}
+}
+
+func BenchmarkBetween(b *testing.B) {
+	b.Run("reflect", func(b *testing.B) {
+		v := Value(42, "age", "年龄").Between(18, 60)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = v.Validate()
+		}
+	})
+	b.Run("generic", func(b *testing.B) {
+		v := BetweenG(Value(42, "age", "年龄"), 18, 60)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = v.Validate()
+		}
+	})
+}
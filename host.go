@@ -0,0 +1,71 @@
+package v
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// isHostname 是否为有效的主机名（RFC 1123），允许以 . 结尾，
+// 总长度不超过 253 个字符，每段标签为 1～63 个字母、数字或连字符，且不能以连字符开头或结尾
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !isHostLabel(label) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHostLabel 是否为有效的主机名标签
+func isHostLabel(label string) bool {
+	if label == "" || len(label) > 63 {
+		return false
+	}
+	if label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// isFQDN 是否为完全限定域名，至少包含两段标签，且顶级域名不能是纯数字
+func isFQDN(s string) bool {
+	if !isHostname(s) {
+		return false
+	}
+	s = strings.TrimSuffix(s, ".")
+	i := strings.LastIndexByte(s, '.')
+	if i < 0 {
+		return false
+	}
+	_, err := strconv.Atoi(s[i+1:])
+	return err != nil
+}
+
+// isHostPort 是否为 host:port 形式的地址，主机可以是主机名、IPv4 地址或以方括号包裹的 IPv6 地址，
+// 如：example.com:8080、127.0.0.1:80、[::1]:443，端口必须在 1～65535 之间
+func isHostPort(s string) bool {
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || host == "" {
+		return false
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return false
+	}
+	if strings.Contains(host, ":") {
+		// IPv6 地址必须使用方括号包裹
+		return strings.HasPrefix(s, "[") && net.ParseIP(host) != nil
+	}
+	return net.ParseIP(host) != nil || isHostname(host)
+}
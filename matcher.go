@@ -1,5 +1,10 @@
 package v
 
+import (
+	"context"
+	"fmt"
+)
+
 type Matcher struct {
 	field    string
 	label    string
@@ -7,13 +12,32 @@ type Matcher struct {
 	branches []branch
 	fallback func(valuer *Valuer) error
 	compare  func(a, b any) bool
+	ctx      *Context
+	all      bool
+	redact   bool // 分支验证器是否在错误信息中对值脱敏
 }
 
 type branch struct {
 	value  any
+	pred   func(value any) bool
 	handle func(valuer *Valuer) error
 }
 
+func (b branch) match(m *Matcher) bool {
+	if b.pred != nil {
+		return b.pred(m.value)
+	}
+	return m.compare(m.value, b.value)
+}
+
+// name 返回分支的子级名称，断言分支没有可用的分支值，统一使用 match
+func (b branch) name() string {
+	if b.pred != nil {
+		return "match"
+	}
+	return fmt.Sprint(b.value)
+}
+
 func Match(value any, field, label string) *Matcher {
 	return &Matcher{
 		field:    field,
@@ -25,27 +49,76 @@ func Match(value any, field, label string) *Matcher {
 	}
 }
 
+// MatchValue 创建匹配验证器并通过 build 声明分支，便于直接传给 Validate 或 Check 使用
+func MatchValue(value any, field, label string, build func(*Matcher)) Validatable {
+	m := Match(value, field, label)
+	if build != nil {
+		build(m)
+	}
+	return m
+}
+
 func (m *Matcher) Branch(value any, handle func(valuer *Valuer) error) *Matcher {
 	m.branches = append(m.branches, branch{value: value, handle: handle})
 	return m
 }
 
+// BranchFunc 添加断言分支，pred 返回 true 时匹配该分支，适用于范围判断等无法使用相等比较的场景
+func (m *Matcher) BranchFunc(pred func(value any) bool, handle func(valuer *Valuer) error) *Matcher {
+	m.branches = append(m.branches, branch{pred: pred, handle: handle})
+	return m
+}
+
+// Using 设置分支值的比较函数，默认使用 ==，
+// 对于切片等不可比较的类型可以使用 reflect.DeepEqual
+func (m *Matcher) Using(compare func(a, b any) bool) *Matcher {
+	m.compare = compare
+	return m
+}
+
 func (m *Matcher) Fallback(handle func(valuer *Valuer) error) *Matcher {
 	m.fallback = handle
 	return m
 }
 
+// WithContext 绑定验证上下文，分支中的验证器共享同一个上下文
+func (m *Matcher) WithContext(c *Context) *Matcher {
+	m.bind(c)
+	return m
+}
+
+func (m *Matcher) bind(c *Context) {
+	m.ctx = c
+	if c != nil {
+		c.setField(m.field, m.value)
+	}
+}
+
+// valuer 创建分支验证器，name 为设置了 SetPathSeparator 时的子级名称
+func (m *Matcher) valuer(ctx context.Context, name string) *Valuer {
+	valuer := Value(m.value, childPath(m.field, name), m.label)
+	valuer.ctx = m.ctx
+	valuer.all = m.all
+	valuer.redact = m.redact
+	valuer.base = ctx
+	return valuer
+}
+
 func (m *Matcher) Validate() error {
+	return m.ValidateContext(context.Background())
+}
+
+// ValidateContext 实现 ValidatableContext 接口，
+// 分支中的验证器调用 Validate 时将使用该上下文
+func (m *Matcher) ValidateContext(ctx context.Context) error {
 	for _, b := range m.branches {
-		if m.compare(m.value, b.value) {
-			valuer := Value(m.value, m.field, m.label)
-			return b.handle(valuer)
+		if b.match(m) {
+			return b.handle(m.valuer(ctx, b.name()))
 		}
 	}
 
 	if m.fallback != nil {
-		valuer := Value(m.value, m.field, m.label)
-		return m.fallback(valuer)
+		return m.fallback(m.valuer(ctx, "fallback"))
 	}
 
 	return nil
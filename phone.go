@@ -0,0 +1,101 @@
+package v
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// phoneRegion 地区的国际电话区号及国内有效号码（不含长途前缀）的格式
+type phoneRegion struct {
+	code    string         // 国际电话区号
+	trunk   string         // 国内长途前缀，如：0
+	pattern *regexp.Regexp // 国内有效号码
+}
+
+// 常用地区的手机及固定电话号码规则，仅校验区号、长度及号段，不保证号码真实存在
+var phoneRegions = map[string]phoneRegion{
+	// 手机号码，或 2 至 4 位区号（不含长途前缀 0）加 7 至 8 位的固定电话号码，如：010 1234 5678
+	"CN": {code: "86", trunk: "0", pattern: regexp.MustCompile(`^(1[3-9]\d{9}|(10|2\d)\d{8}|[3-9]\d{2}\d{7,8})$`)},
+	"HK": {code: "852", pattern: regexp.MustCompile(`^[2-9]\d{7}$`)},
+	"MO": {code: "853", pattern: regexp.MustCompile(`^[26]\d{7}$`)},
+	"TW": {code: "886", trunk: "0", pattern: regexp.MustCompile(`^(9\d{8}|[2-8]\d{7,8})$`)},
+	// 美国与加拿大同属北美编号计划（NANP），共用国际区号 1 及相同的号码格式，
+	// 不校验区号所属的国家，因此美国号码同样可以通过 CA 的验证，反之亦然
+	"US": {code: "1", trunk: "1", pattern: regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`)},
+	"CA": {code: "1", trunk: "1", pattern: regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`)},
+	"GB": {code: "44", trunk: "0", pattern: regexp.MustCompile(`^[1-9]\d{8,9}$`)},
+	"DE": {code: "49", trunk: "0", pattern: regexp.MustCompile(`^[1-9]\d{6,13}$`)},
+	"FR": {code: "33", trunk: "0", pattern: regexp.MustCompile(`^[1-9]\d{8}$`)},
+	"JP": {code: "81", trunk: "0", pattern: regexp.MustCompile(`^[1-9]\d{8,9}$`)},
+	"KR": {code: "82", trunk: "0", pattern: regexp.MustCompile(`^[1-9]\d{7,9}$`)},
+	"SG": {code: "65", pattern: regexp.MustCompile(`^[3689]\d{7}$`)},
+	"AU": {code: "61", trunk: "0", pattern: regexp.MustCompile(`^[2-478]\d{8}$`)},
+	"IN": {code: "91", trunk: "0", pattern: regexp.MustCompile(`^[6-9]\d{9}$`)},
+}
+
+// lookupPhoneRegion 返回地区（不区分大小写）的号码规则
+func lookupPhoneRegion(region string) (phoneRegion, bool) {
+	r, ok := phoneRegions[strings.ToUpper(region)]
+	return r, ok
+}
+
+// mustPhoneRegion 返回地区的号码规则，地区不受支持时将引发 panic
+func mustPhoneRegion(region string) phoneRegion {
+	r, ok := lookupPhoneRegion(region)
+	if !ok {
+		panic(fmt.Errorf("unsupported phone region %q", region))
+	}
+	return r
+}
+
+// NormalizePhoneNumber 将指定地区（如：CN、US）的电话号码转换成 E.164 格式，如：+8613812345678，
+// 号码可以带有国际区号（+86 或 0086）、长途前缀以及空格、连字符、括号等分隔符，号码无效或地区不受支持时返回 false
+func NormalizePhoneNumber(number, region string) (string, bool) {
+	r, ok := lookupPhoneRegion(region)
+	if !ok {
+		return "", false
+	}
+	national, ok := nationalNumber(number, r)
+	if !ok {
+		return "", false
+	}
+	return "+" + r.code + national, true
+}
+
+// PhoneNumberNormalizer 返回可以传给 Custom 的验证函数，号码有效时将其 E.164 格式写入 dst，
+// 以便后续代码保存统一格式的号码
+func PhoneNumberNormalizer(region string, dst *string) func(val any) any {
+	mustPhoneRegion(region)
+	return func(val any) any {
+		normalized, ok := NormalizePhoneNumber(toString(val), region)
+		if ok && dst != nil {
+			*dst = normalized
+		}
+		return ok
+	}
+}
+
+// nationalNumber 去除分隔符、国际区号及长途前缀，返回有效的国内号码
+func nationalNumber(number string, r phoneRegion) (string, bool) {
+	number = strings.Map(func(c rune) rune {
+		switch c {
+		case ' ', '-', '(', ')', '.':
+			return -1
+		}
+		return c
+	}, strings.TrimSpace(number))
+	if rest, found := strings.CutPrefix(number, "+"); found {
+		if number, found = strings.CutPrefix(rest, r.code); !found {
+			return "", false
+		}
+	} else if rest, found := strings.CutPrefix(number, "00"+r.code); found {
+		number = rest
+	} else if r.trunk != "" && !r.pattern.MatchString(number) {
+		number = strings.TrimPrefix(number, r.trunk)
+	}
+	if !r.pattern.MatchString(number) {
+		return "", false
+	}
+	return number, true
+}
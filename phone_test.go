@@ -0,0 +1,40 @@
+package v
+
+import "testing"
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		number string
+		region string
+		want   string
+		ok     bool
+	}{
+		{"138 1234 5678", "CN", "+8613812345678", true},
+		{"+86 138-1234-5678", "cn", "+8613812345678", true},
+		{"0086 13812345678", "CN", "+8613812345678", true},
+		{"+86 10 1234 5678", "CN", "+861012345678", true},
+		{"010-12345678", "CN", "+861012345678", true},
+		{"0755 1234 5678", "CN", "+8675512345678", true},
+		{"12812345678", "CN", "", false},
+		{"+852 2123 4567", "HK", "+85221234567", true},
+		{"(212) 555-1234", "US", "+12125551234", true},
+		{"1 212 555 1234", "CA", "+12125551234", true}, // 北美编号计划不区分国家
+		{"+44 20 7946 0958", "US", "", false},
+		{"138 1234 5678", "XX", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizePhoneNumber(tt.number, tt.region)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizePhoneNumber(%q, %q) = %q, %v, want %q, %v", tt.number, tt.region, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestIsPhoneNumberOfUnknownRegion(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("IsPhoneNumberOf(unknown region) did not panic")
+		}
+	}()
+	Value("13812345678", "phone", "电话").IsPhoneNumberOf("XX")
+}
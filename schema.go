@@ -0,0 +1,84 @@
+package v
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// SchemaValidator 使用已编译的 JSON Schema 验证 JSON 文档（经 json.Unmarshal 解码后的值），
+// 验证失败时返回第一个违规位置的路径（如：/items/0/name）及原因
+type SchemaValidator func(doc any) (path string, err error)
+
+// SchemaCompiler 编译 JSON Schema，返回对应的验证函数，
+// 本包不依赖具体的 JSON Schema 实现，使用 MatchesJSONSchema 前需要通过 SetSchemaCompiler 设置
+type SchemaCompiler func(schema string) (SchemaValidator, error)
+
+var (
+	schemaMu       sync.RWMutex
+	schemaCompiler SchemaCompiler
+	// schemaGen 编译函数的版本，每次调用 SetSchemaCompiler 时递增，
+	// 用于丢弃使用旧编译函数编译的结果
+	schemaGen uint64
+	// 已编译的 JSON Schema，以 Schema 字符串为键
+	schemas sync.Map
+)
+
+// SetSchemaCompiler 设置 JSON Schema 编译函数，并清空已编译的 Schema 缓存
+func SetSchemaCompiler(compiler SchemaCompiler) {
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+	schemaCompiler = compiler
+	schemaGen++
+	schemas.Range(func(key, _ any) bool {
+		schemas.Delete(key)
+		return true
+	})
+}
+
+// compileSchema 编译并缓存 JSON Schema，未设置编译函数或编译失败时返回错误
+func compileSchema(schema string) (SchemaValidator, error) {
+	if validate, ok := schemas.Load(schema); ok {
+		return validate.(SchemaValidator), nil
+	}
+	schemaMu.RLock()
+	compiler, gen := schemaCompiler, schemaGen
+	schemaMu.RUnlock()
+	if compiler == nil {
+		return nil, fmt.Errorf("no JSON schema compiler, call SetSchemaCompiler first")
+	}
+	validate, err := compiler(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %w", err)
+	}
+	schemaMu.RLock()
+	defer schemaMu.RUnlock()
+	// 编译期间编译函数已被替换，不缓存旧编译函数的结果
+	if gen == schemaGen {
+		schemas.Store(schema, validate)
+	}
+	return validate, nil
+}
+
+// toJSONDocument 将 JSON 字符串、[]byte 或其它可以序列化的值转换成 JSON 文档
+func toJSONDocument(val any) (any, bool) {
+	var data []byte
+	switch x := val.(type) {
+	case string:
+		data = []byte(x)
+	case []byte:
+		data = x
+	case json.RawMessage:
+		data = x
+	default:
+		var err error
+		if data, err = json.Marshal(val); err != nil {
+			return nil, false
+		}
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, false
+	}
+	return doc, true
+}
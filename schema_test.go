@@ -0,0 +1,72 @@
+package v
+
+import (
+	"errors"
+	"testing"
+)
+
+// requireKeys 测试用的编译函数，Schema 为文档必须包含的键
+func requireKeys(schema string) (SchemaValidator, error) {
+	if schema == "" {
+		return nil, errors.New("empty schema")
+	}
+	return func(doc any) (string, error) {
+		m, _ := doc.(map[string]any)
+		if _, ok := m[schema]; !ok {
+			return "/" + schema, errors.New("missing")
+		}
+		return "", nil
+	}, nil
+}
+
+func TestMatchesJSONSchema(t *testing.T) {
+	SetSchemaCompiler(nil)
+	defer SetSchemaCompiler(nil)
+
+	// 未设置编译函数时构建验证器不会 panic，验证时返回错误
+	v := Value(`{"name":"bob"}`, "doc", "文档").MatchesJSONSchema("name")
+	if err := v.Validate(); err == nil {
+		t.Error("Validate() without compiler = nil, want an error")
+	}
+
+	SetSchemaCompiler(requireKeys)
+	if err := v.Validate(); err != nil {
+		t.Errorf("Validate(valid) = %v, want nil", err)
+	}
+	err := Value(`{"age":1}`, "doc", "文档").MatchesJSONSchema("name").Validate()
+	if e, ok := err.(*Error); !ok || e.Code() != "json_schema" || e.Params()["path"] != "/name" {
+		t.Errorf("Validate(invalid) = %#v, want a json_schema error at /name", err)
+	}
+	if err := Value(`{}`, "doc", "文档").MatchesJSONSchema("").Validate(); err == nil {
+		t.Error("Validate() with invalid schema = nil, want an error")
+	}
+}
+
+func TestSetSchemaCompilerDuringCompile(t *testing.T) {
+	defer SetSchemaCompiler(nil)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	SetSchemaCompiler(func(schema string) (SchemaValidator, error) {
+		close(started)
+		<-release
+		return func(any) (string, error) { return "/", errors.New("stale") }, nil
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = compileSchema("name")
+	}()
+	<-started
+	SetSchemaCompiler(requireKeys)
+	close(release)
+	<-done
+
+	// 旧编译函数的结果不能在替换之后被缓存
+	validate, err := compileSchema("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := validate(map[string]any{"name": 1}); err != nil {
+		t.Errorf("validate() = %v, want the result of the new compiler", err)
+	}
+}
@@ -0,0 +1,320 @@
+package v
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// StructValidator 基于结构体标签的验证器
+type StructValidator struct {
+	valuers []*Valuer
+}
+
+// structRule 将标签中的规则应用到验证器上，arg 为等号后面的参数，typ 为字段的类型
+type structRule func(v *Valuer, arg string, typ reflect.Type)
+
+// 标签规则名称到验证规则的映射，规则名称与错误代码相同，
+// 以 is_ 开头的规则可以省略前缀，如：email 等同于 is_email
+var structRules = map[string]structRule{
+	"required":                noArg((*Valuer).Required),
+	"not_empty":               noArg((*Valuer).NotEmpty),
+	"is_string":               noArg((*Valuer).IsString),
+	"is_email":                noArg((*Valuer).IsEmail),
+	"is_e164":                 noArg((*Valuer).IsE164),
+	"is_phone_number":         noArg((*Valuer).IsPhoneNumber),
+	"is_url":                  noArg((*Valuer).IsURL),
+	"is_url_encoded":          noArg((*Valuer).IsURLEncoded),
+	"is_base64_url":           noArg((*Valuer).IsBase64URL),
+	"is_semver":               noArg((*Valuer).IsSemver),
+	"is_jwt":                  noArg((*Valuer).IsJwt),
+	"is_uuid":                 noArg((*Valuer).IsUUID),
+	"is_uuid3":                noArg((*Valuer).IsUUID3),
+	"is_uuid4":                noArg((*Valuer).IsUUID4),
+	"is_uuid5":                noArg((*Valuer).IsUUID5),
+	"is_uuid7":                noArg((*Valuer).IsUUID7),
+	"is_uuid_version":         intArg((*Valuer).IsUUIDVersion),
+	"is_ulid":                 noArg((*Valuer).IsULID),
+	"is_md4":                  noArg((*Valuer).IsMD4),
+	"is_md5":                  noArg((*Valuer).IsMD5),
+	"is_sha256":               noArg((*Valuer).IsSHA256),
+	"is_sha384":               noArg((*Valuer).IsSHA384),
+	"is_sha512":               noArg((*Valuer).IsSHA512),
+	"is_ascii":                noArg((*Valuer).IsAscii),
+	"is_alpha":                noArg((*Valuer).IsAlpha),
+	"is_alphanumeric":         noArg((*Valuer).IsAlphanumeric),
+	"is_alpha_unicode":        noArg((*Valuer).IsAlphaUnicode),
+	"is_alphanumeric_unicode": noArg((*Valuer).IsAlphanumericUnicode),
+	"is_numeric":              noArg((*Valuer).IsNumeric),
+	"is_number":               noArg((*Valuer).IsNumber),
+	"is_bool":                 noArg((*Valuer).IsBool),
+	"is_credit_card":          noArg((*Valuer).IsCreditCard),
+	"is_hexadecimal":          noArg((*Valuer).IsHexadecimal),
+	"is_hexcolor":             noArg((*Valuer).IsHexColor),
+	"is_rgb":                  noArg((*Valuer).IsRgb),
+	"is_rgba":                 noArg((*Valuer).IsRgba),
+	"is_hsl":                  noArg((*Valuer).IsHsl),
+	"is_hsla":                 noArg((*Valuer).IsHsla),
+	"is_color":                noArg((*Valuer).IsColor),
+	"is_latitude":             noArg((*Valuer).IsLatitude),
+	"is_longitude":            noArg((*Valuer).IsLongitude),
+	"is_json":                 noArg((*Valuer).IsJson),
+	"is_base64":               noArg((*Valuer).IsBase64),
+	"is_html":                 noArg((*Valuer).IsHTML),
+	"is_html_encoded":         noArg((*Valuer).IsHTMLEncoded),
+	"is_timezone":             noArg((*Valuer).IsTimezone),
+	"is_ipv4":                 noArg((*Valuer).IsIPv4),
+	"is_ipv6":                 noArg((*Valuer).IsIPv6),
+	"is_ip":                   noArg((*Valuer).IsIP),
+	"is_cidr":                 noArg((*Valuer).IsCIDR),
+	"distinct":                noArg((*Valuer).Distinct),
+	"is_byte_size":            noArg((*Valuer).IsByteSize),
+	"is_duration":             noArg((*Valuer).IsDuration),
+	"is_mime_type":            noArg((*Valuer).IsMimeType),
+	"is_slug":                 noArg((*Valuer).IsSlug),
+	"is_positive":             noArg((*Valuer).IsPositive),
+	"is_negative":             noArg((*Valuer).IsNegative),
+	"is_non_negative":         noArg((*Valuer).IsNonNegative),
+	"is_non_positive":         noArg((*Valuer).IsNonPositive),
+	"is_country_code":         noArg((*Valuer).IsCountryCode),
+	"is_country_code3":        noArg((*Valuer).IsCountryCode3),
+	"is_currency_code":        noArg((*Valuer).IsCurrencyCode),
+	"is_language_code":        noArg((*Valuer).IsLanguageCode),
+	"is_hostname":             noArg((*Valuer).IsHostname),
+	"is_fqdn":                 noArg((*Valuer).IsFQDN),
+	"is_host_port":            noArg((*Valuer).IsHostPort),
+	"is_mac":                  noArg((*Valuer).IsMAC),
+	"is_file":                 noArg((*Valuer).IsFile),
+	"is_dir":                  noArg((*Valuer).IsDir),
+	"is_lower":                noArg((*Valuer).IsLower),
+	"is_upper":                noArg((*Valuer).IsUpper),
+	"is_label":                noArg((*Valuer).IsLabel),
+	"is_go_identifier":        noArg((*Valuer).IsGoIdentifier),
+	"is_env_var_name":         noArg((*Valuer).IsEnvVarName),
+	"is_percentage":           noArg((*Valuer).IsPercentage),
+	"no_leading_zeros":        noArg((*Valuer).NoLeadingZeros),
+	"no_nil_items":            noArg((*Valuer).NoNilItems),
+	"strictly_increasing":     noArg((*Valuer).StrictlyIncreasing),
+	"strictly_decreasing":     noArg((*Valuer).StrictlyDecreasing),
+	"is_datetime":             strArg((*Valuer).IsDatetime),
+	"contains":                strArg((*Valuer).Contains),
+	"contains_any":            strArg((*Valuer).ContainsAny),
+	"excludes":                strArg((*Valuer).Excludes),
+	"excludes_all":            strArg((*Valuer).ExcludesAll),
+	"ends_with":               strArg((*Valuer).EndsWith),
+	"ends_not_with":           strArg((*Valuer).EndsNotWith),
+	"starts_with":             strArg((*Valuer).StartsWith),
+	"starts_not_with":         strArg((*Valuer).StartsNotWith),
+	"matches":                 strArg((*Valuer).Matches),
+	"length":                  intArg((*Valuer).Length),
+	"min_length":              intArg((*Valuer).MinLength),
+	"max_length":              intArg((*Valuer).MaxLength),
+	"min_bytes":               intArg((*Valuer).MinBytes),
+	"max_bytes":               intArg((*Valuer).MaxBytes),
+	"min_byte_size":           strArg((*Valuer).MinByteSize),
+	"max_byte_size":           strArg((*Valuer).MaxByteSize),
+	"max_significant_digits":  intArg((*Valuer).MaxSignificantDigits),
+	"ip_version":              intArg((*Valuer).IPVersion),
+	"is_ip_in_cidr":           strArg((*Valuer).IsIPInCIDR),
+	"is_phone_number_of":      strArg((*Valuer).IsPhoneNumberOf),
+	"greater_than":            valueArg((*Valuer).GreaterThan),
+	"greater_equal_than":      valueArg((*Valuer).GreaterEqualThan),
+	"less_than":               valueArg((*Valuer).LessThan),
+	"less_equal_than":         valueArg((*Valuer).LessEqualThan),
+	"equal":                   valueArg((*Valuer).Equal),
+	"not_equal":               valueArg((*Valuer).NotEqual),
+	"length_between": func(v *Valuer, arg string, _ reflect.Type) {
+		args := splitArgs(arg, 2)
+		v.LengthBetween(mustAtoi(args[0]), mustAtoi(args[1]))
+	},
+	"count_between": func(v *Valuer, arg string, _ reflect.Type) {
+		args := splitArgs(arg, 2)
+		v.CountBetween(mustAtoi(args[0]), mustAtoi(args[1]))
+	},
+	"between": func(v *Valuer, arg string, typ reflect.Type) {
+		args := splitArgs(arg, 2)
+		v.Between(parseArg(args[0], typ), parseArg(args[1], typ))
+	},
+	"not_between": func(v *Valuer, arg string, typ reflect.Type) {
+		args := splitArgs(arg, 2)
+		v.NotBetween(parseArg(args[0], typ), parseArg(args[1], typ))
+	},
+	"one_of": func(v *Valuer, arg string, typ reflect.Type) {
+		v.OneOf(parseItems(arg, typ))
+	},
+	"not_one_of": func(v *Valuer, arg string, typ reflect.Type) {
+		v.NotOneOf(parseItems(arg, typ))
+	},
+}
+
+// parseItems 解析以 | 分隔的多个值
+func parseItems(arg string, typ reflect.Type) []any {
+	var items []any
+	for _, item := range strings.Split(arg, "|") {
+		items = append(items, parseArg(item, typ))
+	}
+	return items
+}
+
+func noArg(method func(*Valuer, ...ErrorOption) *Valuer) structRule {
+	return func(v *Valuer, _ string, _ reflect.Type) { method(v) }
+}
+
+func strArg(method func(*Valuer, string, ...ErrorOption) *Valuer) structRule {
+	return func(v *Valuer, arg string, _ reflect.Type) { method(v, arg) }
+}
+
+func intArg(method func(*Valuer, int, ...ErrorOption) *Valuer) structRule {
+	return func(v *Valuer, arg string, _ reflect.Type) { method(v, mustAtoi(arg)) }
+}
+
+func valueArg(method func(*Valuer, any, ...ErrorOption) *Valuer) structRule {
+	return func(v *Valuer, arg string, typ reflect.Type) { method(v, parseArg(arg, typ)) }
+}
+
+// splitArgs 拆分以 | 分隔的多个参数
+func splitArgs(arg string, n int) []string {
+	args := strings.Split(arg, "|")
+	if len(args) != n {
+		panic(fmt.Errorf("expect %d arguments separated by '|', got %q", n, arg))
+	}
+	return args
+}
+
+func mustAtoi(arg string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil {
+		panic(fmt.Errorf("expect an integer argument, got %q", arg))
+	}
+	return n
+}
+
+// parseArg 将参数转换成与字段相同的类型，以便与字段值进行比较
+func parseArg(arg string, typ reflect.Type) any {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	arg = strings.TrimSpace(arg)
+	rv := reflect.New(typ).Elem()
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			panic(fmt.Errorf("expect an integer argument, got %q", arg))
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			panic(fmt.Errorf("expect an unsigned integer argument, got %q", arg))
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			panic(fmt.Errorf("expect a float argument, got %q", arg))
+		}
+		rv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(arg)
+		if err != nil {
+			panic(fmt.Errorf("expect a bool argument, got %q", arg))
+		}
+		rv.SetBool(b)
+	case reflect.String:
+		rv.SetString(arg)
+	default:
+		return arg
+	}
+	return rv.Interface()
+}
+
+// Struct 根据结构体字段的标签创建验证器，如：
+//
+//	type User struct {
+//		Name  string `json:"name" v:"required,min_length=3" label:"用户名"`
+//		Email string `json:"email" v:"required,email" label:"邮箱"`
+//		Age   int    `json:"age" v:"between=18|60" label:"年龄"`
+//	}
+//
+// 规则之间使用逗号分隔，规则参数写在等号后面，多个参数使用 | 分隔；
+// 字段名优先使用 json 标签中的名称，标签 label 用于设置数据标签，未设置时使用字段名；
+// 只处理导出的字段，遇到未知的规则或无效的参数时触发 panic
+func Struct(s any) *StructValidator {
+	rv := indirect(reflect.ValueOf(s))
+	if rv.Kind() != reflect.Struct {
+		panic(fmt.Errorf("expect a struct, got %T", s))
+	}
+	sv := &StructValidator{}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("v")
+		if !ok || tag == "" || tag == "-" {
+			continue
+		}
+		name := field.Name
+		if json, _, _ := strings.Cut(field.Tag.Get("json"), ","); json != "" && json != "-" {
+			name = json
+		}
+		label := field.Tag.Get("label")
+		if label == "" {
+			label = name
+		}
+		qualified := field.Name
+		if rt.Name() != "" {
+			qualified = rt.Name() + "." + field.Name
+		}
+		valuer := Value(rv.Field(i).Interface(), name, label)
+		for _, token := range strings.Split(tag, ",") {
+			token = strings.TrimSpace(token)
+			if token == "" {
+				continue
+			}
+			key, arg, _ := strings.Cut(token, "=")
+			rule, found := structRules[key]
+			if !found {
+				rule, found = structRules["is_"+key]
+			}
+			if !found {
+				panic(fmt.Errorf("unknown validation rule %q on field %s", token, qualified))
+			}
+			applyStructRule(rule, valuer, arg, field.Type, qualified, token)
+		}
+		sv.valuers = append(sv.valuers, valuer)
+	}
+	return sv
+}
+
+// applyStructRule 应用标签规则，参数无效时触发带有字段和规则名称的 panic
+func applyStructRule(rule structRule, v *Valuer, arg string, typ reflect.Type, field, token string) {
+	defer func() {
+		if r := recover(); r != nil {
+			panic(fmt.Errorf("invalid validation rule %q on field %s: %v", token, field, r))
+		}
+	}()
+	rule(v, arg, typ)
+}
+
+// Validate 实现验证器接口，返回的错误集以字段名分组
+func (s *StructValidator) Validate() error {
+	return Validate(s.validations()...)
+}
+
+// ValidateContext 实现 ValidatableContext 接口
+func (s *StructValidator) ValidateContext(ctx context.Context) error {
+	return ValidateContext(ctx, s.validations()...)
+}
+
+func (s *StructValidator) validations() []Validatable {
+	validations := make([]Validatable, len(s.valuers))
+	for i, valuer := range s.valuers {
+		validations[i] = valuer
+	}
+	return validations
+}
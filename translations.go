@@ -1,36 +1,51 @@
 package v
 
 import (
-	"fmt"
 	"reflect"
+	"sync"
 )
 
 // Translator 翻译函数签名
 type Translator func(message string, params map[string]any) string
 
+// translation 错误代码对应的消息模板及翻译函数
+type translation struct {
+	message string
+	trans   Translator
+}
+
 var (
+	// 保护 translations、defaultTranslator 及 locales 的读写锁
+	translationsMu sync.RWMutex
 	// 默认翻译函数
 	defaultTranslator Translator
 	// 预置的翻译信息
-	translations = map[string]struct {
-		message string
-		trans   Translator
-	}{
+	translations = map[string]translation{
 		"required":                {message: "{label}为必填字段"},
 		"required_if":             {message: "{label}为必填字段"},
-		"typeof":                  {trans: typeof},
+		"required_unless":         {message: "{label}为必填字段"},
+		"required_with":           {message: "{label}为必填字段"},
+		"required_without":        {message: "{label}为必填字段"},
+		"required_if_matches":     {message: "{label}为必填字段"},
+		"typeof":                  {message: "{label}不是有效的{type}"},
 		"is_email":                {message: "{label}不是有效的电子邮箱地址"},
 		"is_e164":                 {message: "{label}不是有效的 e.164 手机号码"},
 		"is_phone_number":         {message: "{label}不是有效的手机号码"},
+		"is_phone_number_of":      {message: "{label}不是有效的{region}电话号码"},
 		"is_url":                  {message: "{label}不是有效的链接"},
 		"is_url_encoded":          {message: "{label}不是有效的链接"},
 		"is_base64_url":           {message: "{label}不是有效的BASE64链接"},
 		"is_semver":               {message: "{label}不是有效的语义化版本号"},
+		"semver_no_prerelease":    {message: "{label}不能是预发布版本"},
+		"semver_require_v":        {message: "{label}必须以v开头"},
+		"semver_forbid_v":         {message: "{label}不能以v开头"},
 		"is_jwt":                  {message: "{label}不是有效的权限令牌"},
 		"is_uuid":                 {message: "{label}不是有效的UUID字符串"},
 		"is_uuid3":                {message: "{label}不是有效的V3版UUID字符串"},
 		"is_uuid4":                {message: "{label}不是有效的V4版UUID字符串"},
 		"is_uuid5":                {message: "{label}不是有效的V5版UUID字符串"},
+		"is_uuid7":                {message: "{label}不是有效的V7版UUID字符串"},
+		"is_uuid_version":         {message: "{label}不是有效的V{version}版UUID字符串"},
 		"is_ulid":                 {message: "{label}不是有效的ULID字符串"},
 		"is_md4":                  {message: ""},
 		"is_md5":                  {message: ""},
@@ -43,32 +58,69 @@ var (
 		"is_alpha_unicode":        {message: "{label}只能包含字母和Unicode字符"},
 		"is_alphanumeric_unicode": {message: "{label}只能包含字母数字和Unicode字符"},
 		"is_numeric":              {message: "{label}必须是一个有效的数值"},
+		"numeric":                 {message: "{label}必须是一个有效的数值"},
+		"multiple_of":             {message: "{label}必须是{divisor}的整数倍"},
+		"positive":                {message: "{label}必须大于0"},
+		"negative":                {message: "{label}必须小于0"},
+		"non_negative":            {message: "{label}必须大于或等于0"},
+		"non_positive":            {message: "{label}必须小于或等于0"},
 		"is_number":               {message: "{label}必须是一个有效的数字"},
 		"is_bool":                 {message: "{label}必须是一个有效的布尔值"},
+		"is_credit_card":          {message: "{label}不是有效的信用卡号"},
 		"is_hexadecimal":          {message: "{label}必须是一个有效的十六进制"},
 		"is_hexcolor":             {message: "{label}必须是一个有效的十六进制颜色"},
 		"is_rgb":                  {message: "{label}必须是一个有效的RGB颜色"},
 		"is_rgba":                 {message: "{label}必须是一个有效的RGBA颜色"},
-		"is_hsl":                  {message: "{label}必须是一个有效的RGB颜色"},
+		"is_hsl":                  {message: "{label}必须是一个有效的HSL颜色"},
 		"is_hsla":                 {message: "{label}必须是一个有效的HSLA颜色"},
 		"is_color":                {message: "{label}必须是一个有效的颜色"},
 		"is_latitude":             {message: "{label}必须包含有效的纬度坐标"},
 		"is_longitude":            {message: "{label}必须包含有效的经度坐标"},
 		"is_json":                 {message: "{label}必须是一个JSON字符串"},
+		"json_schema":             {message: "{label}不符合JSON Schema：{path} {reason}"},
 		"is_base64":               {message: "{label}必须是一个有效的Base64字符串"},
 		"is_html":                 {message: "{label}必须是一个有效的网页内容"},
 		"is_html_encoded":         {message: "{label}必须是一个被转义的网页内容"},
 		"is_datetime":             {message: "{label}的格式必须是{layout}"},
+		"after":                   {message: "{label}必须晚于{time}"},
+		"after_or_equal":          {message: "{label}不能早于{time}"},
+		"before":                  {message: "{label}必须早于{time}"},
+		"before_or_equal":         {message: "{label}不能晚于{time}"},
+		"date_between":            {message: "{label}必须在{start}到{end}之间"},
+		"is_duration":             {message: "{label}不是有效的时间段"},
+		"duration_between":        {message: "{label}必须在{min}到{max}之间"},
+		"min_duration":            {message: "{label}不能小于{min}"},
+		"max_duration":            {message: "{label}不能大于{max}"},
+		"is_datetime_any":         {message: "{label}的格式必须是以下之一：{layouts}"},
 		"is_timezone":             {message: "{label}必须是一个有效的时区"},
+		"is_business_day":         {message: "{label}必须是工作日"},
+		"within_business_hours":   {message: "{label}必须在{start}至{end}之间"},
 		"is_ipv4":                 {message: "{label}必须是一个有效的IPv4地址"},
 		"is_ipv6":                 {message: "{label}必须是一个有效的IPv6地址"},
 		"is_ip":                   {message: "{label}必须是一个有效的IP地址"},
+		"is_country_code":         {message: "{label}不是有效的国家或地区代码"},
+		"is_country_code3":        {message: "{label}不是有效的国家或地区代码"},
+		"is_currency_code":        {message: "{label}不是有效的货币代码"},
+		"is_language_code":        {message: "{label}不是有效的语言代码"},
+		"file_max_size":           {message: "{label}的大小不能超过{max}字节"},
+		"file_ext":                {message: "{label}的扩展名必须是以下之一：{exts}"},
+		"is_slug":                 {message: "{label}只能包含小写字母、数字和连字符"},
+		"is_mime_type":            {message: "{label}不是有效的MIME类型"},
+		"mime_type_of":            {message: "{label}必须是以下类型之一：{types}"},
+		"is_cidr":                 {message: "{label}必须是一个有效的CIDR网段"},
+		"is_ip_in_cidr":           {message: "{label}必须是位于{cidr}网段内的IP地址"},
+		"is_hostname":             {message: "{label}必须是一个有效的主机名"},
+		"is_fqdn":                 {message: "{label}必须是一个有效的完全限定域名"},
+		"is_host_port":            {message: "{label}必须是一个有效的主机地址（host:port）"},
+		"ip_version":              {message: "{label}必须是IPv{version}地址，实际为IPv{actual}地址"},
 		"is_mac":                  {message: "{label}必须是一个有效的MAC地址"},
 		"is_file":                 {message: "{label}必须是一个有效的文件"},
 		"is_dir":                  {message: "{label}必须是一个有效的目录"},
 		"is_lower":                {message: "{label}必须是小写字母"},
 		"is_upper":                {message: "{label}必须是大写字母"},
 		"is_label":                {message: "{label}不是有效的{field}"},
+		"is_go_identifier":        {message: "{label}不是有效的Go标识符"},
+		"is_env_var_name":         {message: "{label}不是有效的环境变量名称"},
 		"contains":                {message: "{label}必须包含文本'{substr}'"},
 		"contains_any":            {message: "{label}必须包含至少一个以下字符'{chars}'"},
 		"contains_rune":           {message: "{label}必须包含字符'{rune}'"},
@@ -80,23 +132,62 @@ var (
 		"starts_with":             {message: "{label}必须以文本'{prefix}'开头"},
 		"starts_not_with":         {message: "{label}不能以文本'{prefix}'开头"},
 		"one_of":                  {message: "{label}必须是[{items}]中的一个"},
+		"matches":                 {message: "{label}的格式必须匹配{pattern}"},
+		"matches_any_pattern":     {message: "{label}必须匹配以下模式之一：{patterns}"},
+		"not_one_of":              {message: "{label}不能是[{items}]中的任何一个"},
 		"not_empty":               {message: "{label}不能为空"},
 		"length":                  {message: "{label}长度必须是{length}"},
 		"min_length":              {message: "{label}最小长度为{min}"},
-		"max_length":              {message: "max_length"},
+		"max_length":              {message: "{label}最大长度为{max}"},
+		"min_bytes":               {message: "{label}最少{min}字节，当前为{size}字节"},
+		"max_bytes":               {message: "{label}最多{max}字节，当前为{size}字节"},
+		"is_byte_size":            {message: "{label}不是有效的字节大小"},
+		"min_byte_size":           {message: "{label}不能小于{min}"},
+		"max_byte_size":           {message: "{label}不能大于{max}"},
 		"length_between":          {message: "{label}长度必须大于或等于{min}且小于或等于{max}"},
+		"count":                   {message: "{label}必须是数组、切片或字典"},
+		"count_between":           {message: "{label}的元素个数必须大于或等于{min}且小于或等于{max}"},
 		"greater_than":            {message: "{label}必须大于{min}"},
 		"greater_equal_than":      {message: "{label}必须大于或等于{min}"},
 		"equal":                   {message: "{label}必须等于{another}"},
 		"not_equal":               {message: "{label}不能等于{another}"},
+		"immutable":               {message: "{label}不允许修改，原值为{old}，新值为{new}"},
+		"changed":                 {message: "{label}必须修改，不能与原值{old}相同"},
+		"equal_field":             {message: "{label}必须与{other_label}相同"},
+		"not_equal_field":         {message: "{label}不能与{other_label}相同"},
+		"greater_than_field":      {message: "{label}必须大于{other_label}"},
+		"less_than_field":         {message: "{label}必须小于{other_label}"},
 		"less_equal_than":         {message: "{label}必须小于或等于{max}"},
 		"less_than":               {message: "{label}必须小于{max}"},
 		"between":                 {message: "{label}必须大于或等于{min}且小于或等于{max}"},
 		"not_between":             {message: "{label}必须小于{min}或大于{max}"},
+		"same_sign":               {message: "{label}的正负号必须与{another}一致"},
+		"is_percentage":           {message: "{label}必须是{min}到{max}之间的百分比"},
+		"has_keys":                {message: "{label}缺少以下键：{keys}"},
+		"only_keys":               {message: "{label}不能包含以下键：{keys}"},
+		"strictly_increasing":     {message: "{label}必须严格递增，第{index}项与第{next}项顺序不符"},
+		"strictly_decreasing":     {message: "{label}必须严格递减，第{index}项与第{next}项顺序不符"},
+		"no_nil_items":            {message: "{label}不能包含空元素，位置：{index}"},
+		"balanced_delimiters":     {message: "{label}中的括号或引号不匹配，位置：{position}"},
+		"no_leading_zeros":        {message: "{label}不能以0开头"},
+		"max_significant_digits":  {message: "{label}的有效数字不能超过{max}位"},
 		"some":                    {message: "{label}至少有一个子项通过验证"},
+		"subset":                  {message: "{label}中的{offending}不在允许的范围[{items}]内"},
+		"superset":                {message: "{label}必须包含{offending}"},
+		"not":                     {message: "{label}验证失败"},
+		"some_of":                 {message: "至少需要满足以下条件之一：\n{errors}"},
 		"every":                   {message: "{label}的所有子项必须通过验证"},
+		"distinct":                {message: "{label}中第{index}项与第{duplicate}项重复：{key}"},
+		"unique_across":           {message: "{label}的值{value}重复"},
+		"unique_by_field":         {message: "{label}中第{index}项与第{duplicate}项的{field}重复：{key}"},
 		"entity_exists":           {message: "{label}不存在"},
 		"entity_not_exists":       {message: "{label}已经存在"},
+		"mutually_exclusive":      {message: "{fields}不能同时存在"},
+		"mutually_exclusive_pair": {message: "互斥的字段不能同时存在"},
+		"required_together":       {message: "相关字段必须同时填写"},
+		"at_least_one_of":         {message: "{fields}至少需要填写一项"},
+		"equal_across_fields":     {message: "各字段的值必须保持一致"},
+		"timeout":                 {message: "{label}验证超时"},
 		"index_by":                {message: "参数不完整"},
 	}
 
@@ -125,21 +216,133 @@ var (
 	}
 )
 
-func typeof(_ string, params map[string]any) string {
-	kind := params["kind"].(reflect.Kind)
-	if msg, ok := types[kind]; ok {
-		return fmt.Sprintf("%s不是有效的%s", params["label"], msg)
-	} else {
-		return fmt.Sprintf("%s格式验证失败", params["label"])
+// typeName 返回类型的默认名称，未定义时返回类型的英文名称
+func typeName(kind reflect.Kind) string {
+	if name, ok := types[kind]; ok {
+		return name
+	}
+	return kind.String()
+}
+
+// Locale 语言包
+type Locale struct {
+	Messages   map[string]string       // 错误代码对应的消息模板
+	Types      map[reflect.Kind]string // 类型名称，用于 typeof 错误消息中的 {type} 参数
+	Translator Translator              // 翻译函数，为 nil 时直接替换消息模板中的参数
+}
+
+// typeName 返回类型在语言包中的名称，未定义时返回类型的英文名称
+func (l *Locale) typeName(kind reflect.Kind) string {
+	if name, ok := l.Types[kind]; ok {
+		return name
+	}
+	return kind.String()
+}
+
+// 已注册的语言包
+var locales = map[string]*Locale{
+	"en": {
+		Messages: map[string]string{
+			"typeof": "{label} is not a valid {type}",
+		},
+		Types: map[reflect.Kind]string{
+			reflect.Bool:       "boolean",
+			reflect.Int:        "integer",
+			reflect.Int8:       "integer",
+			reflect.Int16:      "integer",
+			reflect.Int32:      "integer",
+			reflect.Int64:      "integer",
+			reflect.Uint:       "unsigned integer",
+			reflect.Uint8:      "unsigned integer",
+			reflect.Uint16:     "unsigned integer",
+			reflect.Uint32:     "unsigned integer",
+			reflect.Uint64:     "unsigned integer",
+			reflect.Uintptr:    "unsigned integer",
+			reflect.Float32:    "float",
+			reflect.Float64:    "float",
+			reflect.Complex64:  "complex number",
+			reflect.Complex128: "complex number",
+			reflect.Array:      "array",
+			reflect.Map:        "map",
+			reflect.Slice:      "slice",
+			reflect.String:     "string",
+			reflect.Struct:     "struct",
+		},
+	},
+}
+
+// RegisterLocale 注册语言包，同名语言包将被覆盖
+func RegisterLocale(name string, locale *Locale) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	locales[name] = locale
+}
+
+// lookupLocale 返回已注册的语言包
+func lookupLocale(name string) (*Locale, bool) {
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	l, found := locales[name]
+	return l, found
+}
+
+// RegisterTranslation 注册错误代码对应的消息模板及可选的翻译函数，
+// 已存在的错误代码将被覆盖，适用于 Custom 等规则使用的自定义错误代码，可以在运行时并发调用
+func RegisterTranslation(code, message string, trans ...Translator) {
+	t := translation{message: message}
+	if len(trans) > 0 {
+		t.trans = trans[0]
+	}
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	translations[code] = t
+}
+
+// lookupTranslation 返回错误代码对应的翻译信息
+func lookupTranslation(code string) (translation, bool) {
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	t, found := translations[code]
+	return t, found
+}
+
+// SetMessage 设置错误代码对应的默认消息模板，保留已注册的翻译函数，
+// 适用于只需修改个别错误信息而无需替换整个翻译函数的场景
+func SetMessage(code, message string) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+	t := translations[code]
+	t.message = message
+	translations[code] = t
+}
+
+// Message 返回错误代码对应的默认消息模板
+func Message(code string) (string, bool) {
+	t, found := lookupTranslation(code)
+	return t.message, found
+}
+
+// Messages 返回所有错误代码对应的默认消息模板的快照，修改返回值不会影响已注册的消息
+func Messages() map[string]string {
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
+	messages := make(map[string]string, len(translations))
+	for code, t := range translations {
+		messages[code] = t.message
 	}
+	return messages
 }
 
 // SetDefaultTranslator 设置默认翻译函数
 func SetDefaultTranslator(translator Translator) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
 	defaultTranslator = translator
 }
 
 // DefaultTranslator 返回默认翻译函数
 func DefaultTranslator() Translator {
+	translationsMu.RLock()
+	defer translationsMu.RUnlock()
 	return defaultTranslator
 }
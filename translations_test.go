@@ -0,0 +1,42 @@
+package v
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestErrorLocalize(t *testing.T) {
+	RegisterLocale("test-fr", &Locale{
+		Messages: map[string]string{
+			"required": "{label} est obligatoire",
+			"typeof":   "{label} n'est pas un {type} valide",
+		},
+		Types: map[reflect.Kind]string{reflect.String: "chaîne"},
+	})
+
+	required := Value("", "name", "Name").Required().Validate().(*Error)
+	tests := []struct {
+		locale string
+		want   string
+	}{
+		{"en", "Name为必填字段"}, // 英文语言包未定义 required，回退到默认消息
+		{"test-fr", "Name est obligatoire"},
+		{"unknown", "Name为必填字段"},
+	}
+	for _, tt := range tests {
+		if got := required.Localize(tt.locale); got != tt.want {
+			t.Errorf("Localize(%q) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+
+	typeof := Value(1, "name", "Name").Typeof(reflect.String).Validate().(*Error)
+	if got, want := typeof.String(), "Name不是有效的字符串"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := typeof.Localize("en"), "Name is not a valid string"; got != want {
+		t.Errorf(`Localize("en") = %q, want %q`, got, want)
+	}
+	if got, want := typeof.Localize("test-fr"), "Name n'est pas un chaîne valide"; got != want {
+		t.Errorf(`Localize("test-fr") = %q, want %q`, got, want)
+	}
+}
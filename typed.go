@@ -0,0 +1,110 @@
+package v
+
+import "cmp"
+
+// TypedValuer 泛型值验证器，在 Valuer 的基础上提供类型安全的验证规则，
+// 通过 Valuer 方法可以获取底层的验证器，以便继续使用基于 any 的验证规则
+type TypedValuer[T any] struct {
+	valuer *Valuer
+	value  T
+}
+
+// Typed 创建一条泛型值验证器
+func Typed[T any](value T, field, label string) *TypedValuer[T] {
+	return &TypedValuer[T]{
+		valuer: Value(value, field, label),
+		value:  value,
+	}
+}
+
+// typed 返回规则接收到的值，无法断言成 T 时（如 T 为指针类型）返回原始值
+func (t *TypedValuer[T]) typed(val any) T {
+	if x, ok := val.(T); ok {
+		return x
+	}
+	return t.value
+}
+
+// Valuer 返回底层的值验证器，与基于 any 的验证规则共享同一组规则
+func (t *TypedValuer[T]) Valuer() *Valuer {
+	return t.valuer
+}
+
+// Required 值是否必须（值不为空）
+func (t *TypedValuer[T]) Required(options ...ErrorOption) *TypedValuer[T] {
+	t.valuer.Required(options...)
+	return t
+}
+
+// Check 添加类型安全的验证规则
+func (t *TypedValuer[T]) Check(code string, check func(val T) bool, options ...ErrorOption) *TypedValuer[T] {
+	t.valuer.simple(code, func(a any) bool { return check(t.typed(a)) }, options)
+	return t
+}
+
+// Then 使用基于 any 的验证规则
+func (t *TypedValuer[T]) Then(build func(*Valuer)) *TypedValuer[T] {
+	build(t.valuer)
+	return t
+}
+
+// Validate 实现验证器接口
+func (t *TypedValuer[T]) Validate() error {
+	return t.valuer.Validate()
+}
+
+// OrderedValuer 可排序类型（数值、字符串）的泛型值验证器，值的类型为 T 时比较无需反射
+type OrderedValuer[T cmp.Ordered] struct {
+	TypedValuer[T]
+}
+
+// Ordered 创建一条可排序类型的泛型值验证器
+func Ordered[T cmp.Ordered](value T, field, label string) *OrderedValuer[T] {
+	return &OrderedValuer[T]{TypedValuer[T]{
+		valuer: Value(value, field, label),
+		value:  value,
+	}}
+}
+
+// Required 值是否必须（值不为空）
+func (o *OrderedValuer[T]) Required(options ...ErrorOption) *OrderedValuer[T] {
+	o.TypedValuer.Required(options...)
+	return o
+}
+
+// Check 添加类型安全的验证规则
+func (o *OrderedValuer[T]) Check(code string, check func(val T) bool, options ...ErrorOption) *OrderedValuer[T] {
+	o.TypedValuer.Check(code, check, options...)
+	return o
+}
+
+// Then 使用基于 any 的验证规则
+func (o *OrderedValuer[T]) Then(build func(*Valuer)) *OrderedValuer[T] {
+	o.TypedValuer.Then(build)
+	return o
+}
+
+func (o *OrderedValuer[T]) GreaterThan(min T, options ...ErrorOption) *OrderedValuer[T] {
+	GreaterThanG(o.valuer, min, options...)
+	return o
+}
+
+func (o *OrderedValuer[T]) GreaterEqualThan(min T, options ...ErrorOption) *OrderedValuer[T] {
+	GreaterEqualThanG(o.valuer, min, options...)
+	return o
+}
+
+func (o *OrderedValuer[T]) LessThan(max T, options ...ErrorOption) *OrderedValuer[T] {
+	LessThanG(o.valuer, max, options...)
+	return o
+}
+
+func (o *OrderedValuer[T]) LessEqualThan(max T, options ...ErrorOption) *OrderedValuer[T] {
+	LessEqualThanG(o.valuer, max, options...)
+	return o
+}
+
+func (o *OrderedValuer[T]) Between(min, max T, options ...ErrorOption) *OrderedValuer[T] {
+	BetweenG(o.valuer, min, max, options...)
+	return o
+}
@@ -1,7 +1,8 @@
 package v
 
 import (
-	"errors"
+	"context"
+	"sort"
 	"strings"
 
 	"zestack.dev/is"
@@ -12,6 +13,11 @@ type Validatable interface {
 	Validate() error
 }
 
+// ValidatableContext 支持上下文的验证功能接口
+type ValidatableContext interface {
+	ValidateContext(ctx context.Context) error
+}
+
 // Checker 功能验证函数签名
 type Checker func() error
 
@@ -27,40 +33,34 @@ func Wrap(value any, check func(any) error) Checker {
 	}
 }
 
-// Every 每一项都要验证通过
+// Every 每一项都要验证通过，返回第一个未通过验证的错误集
 func Every(validators ...Validatable) Checker {
 	return func() error {
 		for _, validator := range validators {
 			if err := validator.Validate(); err != nil {
-				return err
+				return Join(err)
 			}
 		}
 		return nil
 	}
 }
 
-// Some 任意一项验证通过即可
+// Some 任意一项验证通过即可，全部未通过时返回错误代码为 some_of 的错误，
+// 参数 errors 为包含各项验证错误的错误集（*Errors），便于逐项检查或序列化
 func Some(validators ...Validatable) Checker {
 	return func() error {
 		errs := &Errors{}
-		var hasOk bool
 		for _, validator := range validators {
 			err := validator.Validate()
-			if err != nil {
-				errs.Add(err)
-			} else {
-				hasOk = true
+			if err == nil {
+				return nil
 			}
+			errs.Add(err)
 		}
-		if hasOk || errs.IsEmpty() {
+		if errs.IsEmpty() {
 			return nil
 		}
-		buf := strings.Builder{}
-		buf.WriteString("一下错误至少满足一项：\n")
-		for _, line := range strings.Split(errs.Error(), "\n") {
-			buf.WriteString("  " + line + "\n")
-		}
-		return errors.New(strings.TrimSpace(buf.String()))
+		return NewError("some_of", ErrorParam("errors", errs))
 	}
 }
 
@@ -81,6 +81,60 @@ func Validate(validations ...Validatable) error {
 	return &errs
 }
 
+// ValidateWithLocale 执行多个验证器，返回的错误集将使用指定的语言包（通过 RegisterLocale 注册）格式化错误信息，
+// 适用于同时处理多种语言请求的场景，语言包未注册时使用默认翻译函数
+func ValidateWithLocale(locale string, validations ...Validatable) error {
+	err := Validate(validations...)
+	if errs, ok := err.(*Errors); ok {
+		errs.WithLocale(locale)
+	}
+	return err
+}
+
+// ValidateContext 使用上下文执行多个验证器，实现了 ValidatableContext 接口的验证器将接收到该上下文，
+// 上下文被取消时停止执行后续验证器并返回上下文的错误
+func ValidateContext(ctx context.Context, validations ...Validatable) error {
+	var errs Errors
+	for _, validation := range validations {
+		if validation == nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := validateContext(ctx, validation); err != nil {
+			errs.Add(err)
+		}
+	}
+	if errs.IsEmpty() {
+		return nil
+	}
+	return &errs
+}
+
+// CheckContext 使用上下文逐条执行验证器，一旦验证未通过或上下文被取消，立即返回
+func CheckContext(ctx context.Context, validations ...Validatable) error {
+	for _, validation := range validations {
+		if validation == nil {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := validateContext(ctx, validation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateContext(ctx context.Context, validation Validatable) error {
+	if x, ok := validation.(ValidatableContext); ok {
+		return x.ValidateContext(ctx)
+	}
+	return validation.Validate()
+}
+
 // Check 逐条执行验证器，一旦验证未通过，立即返回
 func Check(validations ...Validatable) error {
 	for _, validation := range validations {
@@ -94,6 +148,15 @@ func Check(validations ...Validatable) error {
 	return nil
 }
 
+// Check1 创建单个值的验证器，通过 build 添加验证规则后立即执行验证
+func Check1(value any, field, label string, build func(*Valuer)) error {
+	valuer := Value(value, field, label)
+	if build != nil {
+		build(valuer)
+	}
+	return valuer.Validate()
+}
+
 // IndexBy 分组验证，只要其中一组验证通过就返回
 func IndexBy(index *int, values [][]any, options ...ErrorOption) Checker {
 	return func() error {
@@ -114,6 +177,85 @@ func IndexBy(index *int, values [][]any, options ...ErrorOption) Checker {
 	}
 }
 
+// MutuallyExclusive 互斥验证，最多只能有一个字段的值不为空
+func MutuallyExclusive(fields map[string]any, options ...ErrorOption) Checker {
+	return func() error {
+		var names []string
+		for name, value := range fields {
+			if !isEmpty(value) {
+				names = append(names, name)
+			}
+		}
+		if len(names) <= 1 {
+			return nil
+		}
+		sort.Strings(names)
+		return NewError("mutually_exclusive", merge(options, ErrorParam("fields", strings.Join(names, ", ")))...)
+	}
+}
+
+// MutuallyExclusivePairs 成对的互斥验证，每一组中的两个值不能同时不为空，
+// 与 MutuallyExclusive 不同，该验证器只接收值而没有字段名，错误参数 index 为未通过验证的组的索引
+func MutuallyExclusivePairs(pairs [][2]any, options ...ErrorOption) Checker {
+	return func() error {
+		for i, pair := range pairs {
+			if !isEmpty(pair[0]) && !isEmpty(pair[1]) {
+				return NewError("mutually_exclusive_pair", merge(options, ErrorParam("index", i))...)
+			}
+		}
+		return nil
+	}
+}
+
+// RequiredTogether 多个值必须同时提供或同时为空，如：用户名与密码，
+// 错误参数 index 为第一个为空的值的索引
+func RequiredTogether(values []any, options ...ErrorOption) Checker {
+	return func() error {
+		index, provided := -1, false
+		for i, value := range values {
+			if isEmpty(value) {
+				if index < 0 {
+					index = i
+				}
+			} else {
+				provided = true
+			}
+		}
+		if provided && index >= 0 {
+			return NewError("required_together", merge(options, ErrorParam("index", index))...)
+		}
+		return nil
+	}
+}
+
+// AtLeastOneOf 至少有一个字段的值不为空
+func AtLeastOneOf(fields map[string]any, options ...ErrorOption) Checker {
+	return func() error {
+		names := make([]string, 0, len(fields))
+		for name, value := range fields {
+			if !isEmpty(value) {
+				return nil
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return NewError("at_least_one_of", merge(options, ErrorParam("fields", strings.Join(names, ", ")))...)
+	}
+}
+
+// EqualAcrossFields 多个字段的值必须全部相等，如：密码与确认密码，
+// 错误参数 index 为第一个不相等的值的索引，错误中不包含字段的值
+func EqualAcrossFields(values []any, options ...ErrorOption) Checker {
+	return func() error {
+		for i := 1; i < len(values); i++ {
+			if !is.Equal(values[i], values[0]) {
+				return NewError("equal_across_fields", merge(options, ErrorParam("index", i))...)
+			}
+		}
+		return nil
+	}
+}
+
 // Map 通过 map 构建值验证器
 func Map(data map[string]any) func(name, label string) *Valuer {
 	return func(name, label string) *Valuer {
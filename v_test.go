@@ -0,0 +1,108 @@
+package v
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEvery(t *testing.T) {
+	ok := Value("bob", "name", "姓名").Required()
+	if err := Every(ok, ok)(); err != nil {
+		t.Fatalf("Every(passing) = %v, want nil", err)
+	}
+
+	err := Every(ok, Value("", "email", "邮箱").Required(), Value("", "phone", "电话").Required())()
+	errs, isErrors := err.(*Errors)
+	if !isErrors {
+		t.Fatalf("Every(failing) = %T, want *Errors", err)
+	}
+	m := errs.ToMap()
+	if len(m) != 1 || len(m["email"]) != 1 || m["email"][0].Code() != "required" {
+		t.Errorf("Every(failing).ToMap() = %v, want only the first failure on email", m)
+	}
+}
+
+func TestSome(t *testing.T) {
+	email := Value("", "email", "邮箱").Required()
+	phone := Value("", "phone", "电话").Required()
+	if err := Some(email, Value("123", "phone", "电话").Required())(); err != nil {
+		t.Fatalf("Some(one passing) = %v, want nil", err)
+	}
+
+	err := Some(email, phone)()
+	e, ok := err.(*Error)
+	if !ok || e.Code() != "some_of" {
+		t.Fatalf("Some(all failing) = %#v, want a some_of *Error", err)
+	}
+	errs, ok := e.Params()["errors"].(*Errors)
+	if !ok {
+		t.Fatalf("params[errors] = %T, want *Errors", e.Params()["errors"])
+	}
+	if len(errs.Get("email")) != 1 || len(errs.Get("phone")) != 1 {
+		t.Errorf("params[errors].ToMap() = %v, want one error for email and phone", errs.ToMap())
+	}
+}
+
+func TestMutuallyExclusivePairs(t *testing.T) {
+	if err := MutuallyExclusivePairs([][2]any{{"a", ""}, {"", "b"}})(); err != nil {
+		t.Errorf("MutuallyExclusivePairs(valid) = %v, want nil", err)
+	}
+	err := MutuallyExclusivePairs([][2]any{{"a", ""}, {"b", "c"}})()
+	e, ok := err.(*Error)
+	if !ok || e.Code() != "mutually_exclusive_pair" || e.Params()["index"] != 1 {
+		t.Fatalf("MutuallyExclusivePairs(invalid) = %#v, want mutually_exclusive_pair at index 1", err)
+	}
+	if got, want := e.String(), "互斥的字段不能同时存在"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	err = MutuallyExclusivePairs([][2]any{{"a", "b"}}, ErrorCode("conflict"))()
+	if e, ok := err.(*Error); !ok || e.Code() != "conflict" {
+		t.Errorf("MutuallyExclusivePairs(ErrorCode) = %#v, want code conflict", err)
+	}
+}
+
+func TestRequiredTogether(t *testing.T) {
+	for _, values := range [][]any{{"", ""}, {"bob", "secret"}, nil} {
+		if err := RequiredTogether(values)(); err != nil {
+			t.Errorf("RequiredTogether(%q) = %v, want nil", values, err)
+		}
+	}
+	err := RequiredTogether([]any{"bob", ""}, ErrorFormat("请同时填写用户名和密码"))()
+	e, ok := err.(*Error)
+	if !ok || e.Code() != "required_together" || e.Params()["index"] != 1 {
+		t.Fatalf("RequiredTogether(invalid) = %#v, want required_together at index 1", err)
+	}
+	if got, want := e.String(), "请同时填写用户名和密码"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestSomeMessage(t *testing.T) {
+	RegisterLocale("test-some", &Locale{Messages: map[string]string{
+		"some_of":  "one of:\n{errors}",
+		"required": "{label} is required",
+	}})
+	boom := Checker(func() error { return errors.New("boom") })
+	err := Some(Value("", "email", "邮箱").Required(), boom)().(*Error)
+
+	if got, want := err.String(), "至少需要满足以下条件之一：\n邮箱为必填字段\nboom"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got, want := err.Localize("test-some"), "one of:\n邮箱 is required\nboom"; got != want {
+		t.Errorf("Localize() = %q, want %q", got, want)
+	}
+}
+
+func TestEqualAcrossFields(t *testing.T) {
+	if err := EqualAcrossFields([]any{"s3cret", "s3cret"})(); err != nil {
+		t.Errorf("EqualAcrossFields(equal) = %v, want nil", err)
+	}
+	err := EqualAcrossFields([]any{"s3cret", "s3cret", "hunter2"})()
+	e, ok := err.(*Error)
+	if !ok || e.Code() != "equal_across_fields" {
+		t.Fatalf("EqualAcrossFields(different) = %#v, want an equal_across_fields error", err)
+	}
+	if got := e.Params(); len(got) != 1 || got["index"] != 2 {
+		t.Errorf("params = %v, want only index 2", got)
+	}
+}
@@ -0,0 +1,49 @@
+package v
+
+import "sync"
+
+// Validator 可复用的验证器，每个字段的验证规则只构建一次，之后在多次验证之间复用，
+// 避免每次请求都重新构建验证链，适用于高吞吐量的服务。
+// Validator 可以被多个协程并发使用，但构建函数不能依赖具体的值（如：When 的条件），
+// 因为值只在验证时才会被设置
+type Validator struct {
+	fields []*validatorField
+}
+
+type validatorField struct {
+	field string
+	pool  sync.Pool
+}
+
+// NewValidator 创建可复用的验证器
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Field 添加字段验证规则
+func (r *Validator) Field(field, label string, build func(*Valuer)) *Validator {
+	f := &validatorField{field: field}
+	f.pool.New = func() any {
+		valuer := Value(nil, field, label)
+		build(valuer)
+		return valuer
+	}
+	r.fields = append(r.fields, f)
+	return r
+}
+
+// Validate 使用预先构建的验证规则验证数据
+func (r *Validator) Validate(data map[string]any) *Errors {
+	var errs Errors
+	for _, f := range r.fields {
+		valuer := f.pool.Get().(*Valuer)
+		valuer.value = data[f.field]
+		errs.Add(valuer.Validate())
+		valuer.value = nil
+		f.pool.Put(valuer)
+	}
+	if errs.IsEmpty() {
+		return nil
+	}
+	return &errs
+}
@@ -0,0 +1,46 @@
+package v
+
+import "testing"
+
+func TestValidator(t *testing.T) {
+	validator := NewValidator().
+		Field("name", "姓名", func(v *Valuer) { v.Required() }).
+		Field("age", "年龄", func(v *Valuer) { v.Required().Between(1, 150) })
+	if errs := validator.Validate(map[string]any{"name": "bob", "age": 30}); errs != nil {
+		t.Errorf("Validate(valid) = %v, want nil", errs)
+	}
+	errs := validator.Validate(map[string]any{"age": 200})
+	if errs == nil || !errs.Has("name") || !errs.Has("age") {
+		t.Errorf("Validate(invalid) = %v, want errors on name and age", errs)
+	}
+}
+
+var benchmarkData = map[string]any{
+	"name":  "bob",
+	"email": "bob@example.com",
+	"age":   30,
+}
+
+func BenchmarkValidator(b *testing.B) {
+	b.Run("construct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = Validate(
+				Value(benchmarkData["name"], "name", "姓名").Required().MinLength(2).MaxLength(32),
+				Value(benchmarkData["email"], "email", "邮箱").Required().IsEmail(),
+				Value(benchmarkData["age"], "age", "年龄").Required().Between(1, 150),
+			)
+		}
+	})
+	b.Run("reuse", func(b *testing.B) {
+		validator := NewValidator().
+			Field("name", "姓名", func(v *Valuer) { v.Required().MinLength(2).MaxLength(32) }).
+			Field("email", "邮箱", func(v *Valuer) { v.Required().IsEmail() }).
+			Field("age", "年龄", func(v *Valuer) { v.Required().Between(1, 150) })
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = validator.Validate(benchmarkData)
+		}
+	})
+}
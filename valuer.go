@@ -1,9 +1,25 @@
 package v
 
 import (
+	"context"
 	"fmt"
+	"go/token"
+	"math"
+	"mime"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
 	"reflect"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"zestack.dev/is"
 )
@@ -11,13 +27,30 @@ import (
 // Ruler 规则验证函数签名
 type Ruler func(any) error
 
+// contextRuler 接收上下文的规则验证函数签名
+type contextRuler func(ctx context.Context, val any) error
+
 // Valuer 基本值验证器
 type Valuer struct {
-	field    string    // 字段名称，如：username
-	label    string    // 数据标签，对应字段名，如：用户名
-	value    any       // 参与验证的值
-	requires []Checker // 空值验证器列表
-	rules    []Ruler   // 参与验证的规则列表
+	field    string                // 字段名称，如：username
+	label    string                // 数据标签，对应字段名，如：用户名
+	value    any                   // 参与验证的值
+	requires []Checker             // 空值验证器列表
+	rules    []contextRuler        // 参与验证的规则列表
+	redact   bool                  // 是否在错误信息中对值脱敏
+	skipped  bool                  // 最近一次验证是否因值为空而跳过了验证规则
+	ctx      *Context              // 验证上下文
+	base     context.Context       // 调用 Validate 时使用的上下文，为 nil 时使用 context.Background()
+	hooks    []func(*Error) *Error // 错误处理函数列表
+	all      bool                  // 是否执行全部规则并收集所有错误
+	trans    []func(any) any       // 执行验证规则前对值进行转换的函数列表
+	infos    []RuleInfo            // 已添加的规则的元数据
+}
+
+// RuleInfo 规则的元数据，可用于生成等价的客户端验证规则
+type RuleInfo struct {
+	Code   string         // 错误代码，如：min_length
+	Params map[string]any // 错误参数，如：{"min": 8}
 }
 
 // Value 创建一条验证器
@@ -27,15 +60,31 @@ func Value(value any, field, label string) *Valuer {
 		label:    label,
 		value:    value,
 		requires: []Checker{},
-		rules:    []Ruler{},
+		rules:    []contextRuler{},
 	}
 }
 
-// Validate 实现验证器接口
+// Validate 实现验证器接口，
+// 值为空（参考 isEmpty）时仅执行空值验证器，否则执行验证规则
 func (v *Valuer) Validate() error {
-	if is.Empty(v.value) {
+	ctx := v.base
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return v.ValidateContext(ctx)
+}
+
+// ValidateContext 实现 ValidatableContext 接口，上下文将传递给每条验证规则，
+// 上下文被取消时停止执行后续规则并返回上下文的错误
+func (v *Valuer) ValidateContext(ctx context.Context) error {
+	if v.ctx != nil {
+		ctx = context.WithValue(ctx, contextKey{}, v.ctx)
+		v.ctx.setField(v.field, v.value)
+	}
+	v.skipped = isEmpty(v.value)
+	if v.skipped {
 		for _, require := range v.requires {
-			if err := require(); err != nil {
+			if err := v.handle(require()); err != nil {
 				return err
 			}
 		}
@@ -55,14 +104,179 @@ func (v *Valuer) Validate() error {
 		}
 	}
 
+	// transform
+	for _, fn := range v.trans {
+		value = fn(value)
+	}
+
 	// call rules
+	var errs *Errors
 	for _, rule := range v.rules {
-		if err := rule(value); err != nil {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
+		if err := v.handle(rule(ctx, value)); err != nil {
+			if !v.all {
+				return err
+			}
+			if errs == nil {
+				errs = &Errors{}
+			}
+			errs.Add(err)
+		}
+	}
+	if errs.IsEmpty() {
+		return nil
+	}
+	return errs
+}
+
+// Transform 添加转换函数，在空值检查之后、执行验证规则之前对值进行转换（如：去除首尾空白），
+// 多个转换函数按添加顺序依次执行，转换结果仅用于验证规则，错误信息中的值依然是原始值
+func (v *Valuer) Transform(fn func(any) any) *Valuer {
+	v.trans = append(v.trans, fn)
+	return v
+}
+
+// TrimSpace 验证前去除字符串值的首尾空白，非字符串值保持不变
+func (v *Valuer) TrimSpace() *Valuer {
+	return v.Transform(func(a any) any {
+		if s, ok := a.(string); ok {
+			return strings.TrimSpace(s)
+		}
+		return a
+	})
+}
+
+// ToLower 验证前将字符串值转换成小写，非字符串值保持不变
+func (v *Valuer) ToLower() *Valuer {
+	return v.Transform(func(a any) any {
+		if s, ok := a.(string); ok {
+			return strings.ToLower(s)
+		}
+		return a
+	})
+}
+
+// All 执行全部验证规则并返回包含所有错误的错误集，而不是在第一条规则失败时立即返回，
+// 空值验证器依然在第一个错误时返回，通过 When、Match 创建的嵌套验证器同样执行全部规则
+func (v *Valuer) All() *Valuer {
+	v.all = true
+	return v
+}
+
+// OnError 添加错误处理函数，在错误返回之前对其进行修改（如：修改错误代码、添加参数），
+// 处理函数返回 nil 时将忽略该错误，多个处理函数按添加顺序依次执行
+func (v *Valuer) OnError(fn func(*Error) *Error) *Valuer {
+	v.hooks = append(v.hooks, fn)
+	return v
+}
+
+// handle 使用错误处理函数处理验证产生的错误
+func (v *Valuer) handle(err error) error {
+	if err == nil || len(v.hooks) == 0 {
+		return err
+	}
+	errs := &Errors{}
+	errs.Add(err)
+	handled := &Errors{}
+	for _, e := range errs.errors {
+		if e.field == "" && e.code == "" {
+			e = v.mistake(e.error)
+		}
+		for _, hook := range v.hooks {
+			if e = hook(e); e == nil {
+				break
+			}
+		}
+		handled.Add(e)
+	}
+	if handled.IsEmpty() {
+		return nil
+	}
+	if _, ok := err.(*Errors); !ok && len(handled.errors) == 1 {
+		return handled.errors[0]
+	}
+	return handled
+}
+
+// WithContext 绑定验证上下文，并将当前字段值写入上下文，
+// 通过 When、Match 等创建的嵌套验证器共享同一个上下文
+func (v *Valuer) WithContext(c *Context) *Valuer {
+	v.bind(c)
+	return v
+}
+
+func (v *Valuer) bind(c *Context) {
+	v.ctx = c
+	if c != nil {
+		c.setField(v.field, v.value)
+	}
+}
+
+// Context 返回绑定的验证上下文，未绑定时创建并绑定一个新的上下文
+func (v *Valuer) Context() *Context {
+	if v.ctx == nil {
+		v.bind(NewContext())
+	}
+	return v.ctx
+}
+
+var (
+	// pathSeparator 嵌套验证器的字段名分隔符，为空时嵌套验证器沿用父级字段名
+	pathSeparator   string
+	pathSeparatorMu sync.RWMutex
+)
+
+// SetPathSeparator 设置嵌套验证器（When、Match）的字段名分隔符，
+// 设置后嵌套验证器的字段名为父级字段名与子级名称的组合，如：payment.when、payment.card，
+// 以便区分嵌套验证器与父级验证器返回的错误，默认为空（不改变字段名）
+func SetPathSeparator(sep string) {
+	pathSeparatorMu.Lock()
+	defer pathSeparatorMu.Unlock()
+	pathSeparator = sep
+}
+
+// getPathSeparator 返回通过 SetPathSeparator 设置的分隔符
+func getPathSeparator() string {
+	pathSeparatorMu.RLock()
+	defer pathSeparatorMu.RUnlock()
+	return pathSeparator
+}
+
+// fieldSeparator 返回连接嵌套字段名的分隔符，未设置 SetPathSeparator 时为 .
+func fieldSeparator() string {
+	if sep := getPathSeparator(); sep != "" {
+		return sep
+	}
+	return "."
+}
+
+// childPath 使用 pathSeparator 组合父级字段名与子级名称
+func childPath(parent, child string) string {
+	sep := getPathSeparator()
+	if sep == "" || child == "" {
+		return parent
+	}
+	if parent == "" {
+		return child
 	}
+	return parent + sep + child
+}
+
+// sub 创建继承当前验证器设置的嵌套验证器
+func (v *Valuer) sub(name string) *Valuer {
+	x := Value(v.value, childPath(v.field, name), v.label)
+	x.redact = v.redact
+	x.ctx = v.ctx
+	x.all = v.all
+	x.trans = slices.Clip(v.trans)
+	return x
+}
 
-	return nil
+// LastRunSkipped 返回最近一次验证是否因值为空而跳过了全部验证规则
+func (v *Valuer) LastRunSkipped() bool {
+	return v.skipped
 }
 
 func (v *Valuer) mistake(err error, options ...ErrorOption) *Error {
@@ -73,6 +287,7 @@ func (v *Valuer) mistake(err error, options ...ErrorOption) *Error {
 	e.field = v.field
 	e.label = v.label
 	e.value = v.value
+	e.redact = v.redact
 	for _, option := range options {
 		option(e)
 	}
@@ -84,15 +299,58 @@ func (v *Valuer) newError(code string, options []ErrorOption) *Error {
 	e.label = v.label
 	e.value = v.value
 	e.field = v.field
+	if v.redact {
+		e.redact = true
+	}
 	return e
 }
 
+// WithLabel 修改数据标签，已添加的规则在验证时同样使用新的标签
+func (v *Valuer) WithLabel(label string) *Valuer {
+	v.label = label
+	return v
+}
+
+// WithField 修改字段名称，已添加的规则在验证时同样使用新的字段名称
+func (v *Valuer) WithField(field string) *Valuer {
+	v.field = field
+	return v
+}
+
+// RedactValue 在错误信息中对值进行脱敏处理，适用于密码、令牌等敏感字段
+func (v *Valuer) RedactValue() *Valuer {
+	v.redact = true
+	return v
+}
+
 func (v *Valuer) addRule(rule Ruler) *Valuer {
+	return v.addContextRule(func(_ context.Context, val any) error {
+		return rule(val)
+	})
+}
+
+func (v *Valuer) addContextRule(rule contextRuler) *Valuer {
 	v.rules = append(v.rules, rule)
 	return v
 }
 
+// describe 记录规则的元数据，错误选项中的 ErrorCode 将覆盖默认的错误代码
+func (v *Valuer) describe(code string, options []ErrorOption) {
+	e := NewError(code, options...)
+	v.infos = append(v.infos, RuleInfo{Code: e.code, Params: e.Params()})
+}
+
+// Describe 返回已添加的规则的元数据，包括 Required 及基于 simple、numeric 等内部构建函数的规则，
+// 直接通过 Custom 等方式添加的规则没有元数据；When、Unless 等嵌套验证只记录其本身（如：when），
+// 嵌套的规则在验证时才会构建，因此不包含在内
+func (v *Valuer) Describe() []RuleInfo {
+	infos := make([]RuleInfo, len(v.infos))
+	copy(infos, v.infos)
+	return infos
+}
+
 func (v *Valuer) simple(code string, check func(any) bool, options []ErrorOption) *Valuer {
+	v.describe(code, options)
 	return v.addRule(func(val any) error {
 		if check(val) {
 			return nil
@@ -105,31 +363,132 @@ func (v *Valuer) string(code string, check func(string) bool, options []ErrorOpt
 	return v.simple(code, func(a any) bool { return check(toString(a)) }, options)
 }
 
-func (v *Valuer) Custom(code string, check func(val any) any, options ...ErrorOption) *Valuer {
-	v.rules = append(v.rules, func(val any) error {
-		if res := check(val); res == false {
-			return v.newError(code, options) // 验证失败
-		} else if res == true || res == nil {
-			return nil // 验证成功
-		} else if err, ok := res.(error); ok {
-			if isBuiltinError(err) {
+// RuleTimeout 为最近一次直接添加到当前验证器的规则设置超时时间，规则将在独立的协程中执行，
+// 超时后立即返回 timeout 错误。超时的规则将在后台继续执行直至返回，其结果会被丢弃，
+// 通过 CustomContext 添加的规则可以监听上下文以便及时退出。
+// 注意 When、Match 等方法添加的是一条包含整个嵌套验证器的规则，Required 等空值验证器不是规则，
+// 需要明确指定超时范围时请使用 Timeout。当前验证器还没有规则时将引发 panic
+func (v *Valuer) RuleTimeout(d time.Duration, options ...ErrorOption) *Valuer {
+	n := len(v.rules)
+	if n == 0 {
+		panic("RuleTimeout must be called after adding a rule")
+	}
+	v.rules[n-1] = v.timeout(v.rules[n-1], d, options)
+	return v
+}
+
+// Timeout 为 build 添加的全部规则设置超时时间，这些规则作为一个整体在独立的协程中执行，
+// 超时后返回 timeout 错误，其它行为与 RuleTimeout 相同
+func (v *Valuer) Timeout(d time.Duration, build func(*Valuer), options ...ErrorOption) *Valuer {
+	x := v.sub("")
+	build(x)
+	return v.addContextRule(v.timeout(func(ctx context.Context, _ any) error {
+		return x.ValidateContext(ctx)
+	}, d, options))
+}
+
+// rulePanic 规则在超时协程中引发的 panic，保留了协程的调用栈
+type rulePanic struct {
+	value any
+	stack []byte
+}
+
+func (p *rulePanic) Error() string {
+	return fmt.Sprintf("%v\n\ngoroutine stack:\n%s", p.value, p.stack)
+}
+
+// Unwrap 返回 panic 的值为错误时的原始错误
+func (p *rulePanic) Unwrap() error {
+	err, _ := p.value.(error)
+	return err
+}
+
+// timeout 返回在独立的协程中执行 rule 并限制其执行时间的规则，
+// rule 引发的 panic 将连同协程的调用栈在当前协程中重新引发
+func (v *Valuer) timeout(rule contextRuler, d time.Duration, options []ErrorOption) contextRuler {
+	return func(parent context.Context, val any) error {
+		ctx, cancel := context.WithTimeout(parent, d)
+		defer cancel()
+		type result struct {
+			err   error
+			panic *rulePanic
+		}
+		done := make(chan result, 1) // 带缓冲，超时后协程也能正常退出
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					done <- result{panic: &rulePanic{value: r, stack: debug.Stack()}}
+				}
+			}()
+			done <- result{err: rule(ctx, val)}
+		}()
+		select {
+		case res := <-done:
+			if res.panic != nil {
+				panic(res.panic)
+			}
+			return res.err
+		case <-ctx.Done():
+			if err := parent.Err(); err != nil {
 				return err
 			}
-			m := v.newError(code, options)
-			m.error = err
-			//if str := err.Error(); m.format != "" && str != "" {
-			//	m.format = fmt.Sprintf("%s(%s)", m.format, str)
-			//}
-			return m
-		} else {
-			panic("must return a bool, a nil or a error pointer")
+			return v.newError("timeout", merge(options, ErrorParam("timeout", d)))
+		}
+	}
+}
+
+// resolve 将自定义验证函数的返回值转换成错误，返回值为 Validatable 时使用 ctx 执行其验证并返回验证结果
+func (v *Valuer) resolve(ctx context.Context, code string, res any, options []ErrorOption) error {
+	if res == false {
+		return v.newError(code, options) // 验证失败
+	} else if res == true || res == nil {
+		return nil // 验证成功
+	} else if err, ok := res.(error); ok {
+		if isBuiltinError(err) {
+			return err
 		}
+		m := v.newError(code, options)
+		m.error = err
+		//if str := err.Error(); m.format != "" && str != "" {
+		//	m.format = fmt.Sprintf("%s(%s)", m.format, str)
+		//}
+		return m
+	} else if x, ok := res.(Validatable); ok {
+		// 委托给其它验证器（如：验证解析后的值）
+		return v.resolve(ctx, code, validateContext(ctx, x), options)
+	} else {
+		panic("must return a bool, a nil, a error or a Validatable")
+	}
+}
+
+func (v *Valuer) Custom(code string, check func(val any) any, options ...ErrorOption) *Valuer {
+	return v.addContextRule(func(ctx context.Context, val any) error {
+		return v.resolve(ctx, code, check(val), options)
+	})
+}
+
+// CustomContext 自定义验证规则，验证函数可以通过上下文获取取消信号或截止时间，
+// 适用于查询数据库等耗时的验证
+func (v *Valuer) CustomContext(code string, check func(ctx context.Context, val any) any, options ...ErrorOption) *Valuer {
+	return v.addContextRule(func(ctx context.Context, val any) error {
+		return v.resolve(ctx, code, check(ctx, val), options)
+	})
+}
+
+// CustomWith 自定义验证规则，可在验证函数中通过 set 设置错误参数
+func (v *Valuer) CustomWith(code string, check func(val any, set func(key string, value any)) any, options ...ErrorOption) *Valuer {
+	return v.addContextRule(func(ctx context.Context, val any) error {
+		var params []ErrorOption
+		res := check(val, func(key string, value any) {
+			params = append(params, ErrorParam(key, value))
+		})
+		return v.resolve(ctx, code, res, merge(options, params...))
 	})
-	return v
 }
 
 // Required 值是否必须（值不为空）
 func (v *Valuer) Required(options ...ErrorOption) *Valuer {
+	v.describe("required", options)
 	v.requires = append(v.requires, func() error {
 		return v.newError("required", options)
 	})
@@ -138,6 +497,7 @@ func (v *Valuer) Required(options ...ErrorOption) *Valuer {
 
 // RequiredIf 满足条件必须
 func (v *Valuer) RequiredIf(condition bool, options ...ErrorOption) *Valuer {
+	v.describe("required_if", options)
 	v.requires = append(v.requires, func() error {
 		if condition {
 			return v.newError("required_if", options)
@@ -150,9 +510,10 @@ func (v *Valuer) RequiredIf(condition bool, options ...ErrorOption) *Valuer {
 
 // RequiredWith 依赖其它值判断是否必须
 func (v *Valuer) RequiredWith(values []any, options ...ErrorOption) *Valuer {
+	v.describe("required_with", options)
 	v.requires = append(v.requires, func() error {
 		for _, value := range values {
-			if !is.Empty(value) {
+			if !isEmpty(value) {
 				return v.newError("required_with", options)
 			}
 		}
@@ -162,29 +523,212 @@ func (v *Valuer) RequiredWith(values []any, options ...ErrorOption) *Valuer {
 	return v
 }
 
+// RequiredUnless 不满足条件时必须
+func (v *Valuer) RequiredUnless(condition bool, options ...ErrorOption) *Valuer {
+	v.describe("required_unless", options)
+	v.requires = append(v.requires, func() error {
+		if !condition {
+			return v.newError("required_unless", options)
+		}
+		return nil
+	})
+
+	return v
+}
+
+// RequiredWithout 依赖的其它值中任意一个为空时必须
+func (v *Valuer) RequiredWithout(values []any, options ...ErrorOption) *Valuer {
+	v.describe("required_without", options)
+	v.requires = append(v.requires, func() error {
+		for _, value := range values {
+			if isEmpty(value) {
+				return v.newError("required_without", options)
+			}
+		}
+		return nil
+	})
+
+	return v
+}
+
+// RequiredIfMatches 另一个值匹配正则表达式 pattern 时必须
+func (v *Valuer) RequiredIfMatches(another any, pattern string, options ...ErrorOption) *Valuer {
+	v.describe("required_if_matches", merge(options, ErrorParam("pattern", pattern)))
+	re := compileRegexp(pattern)
+	v.requires = append(v.requires, func() error {
+		if another != nil && re.MatchString(toString(another)) {
+			return v.newError("required_if_matches", merge(options, ErrorParam("pattern", pattern)))
+		}
+		return nil
+	})
+	return v
+}
+
 func (v *Valuer) When(condition bool, then func(*Valuer)) *Valuer {
 	if condition && then != nil {
-		v.addRule(func(a any) error {
-			x := Value(v.value, v.field, v.label)
+		v.describe("when", nil)
+		v.addContextRule(func(ctx context.Context, a any) error {
+			x := v.sub("when")
+			then(x)
+			return x.ValidateContext(ctx)
+		})
+	}
+	return v
+}
+
+// Rules 将多组验证规则组合成一组可复用的验证规则，如：在注册、重置密码等多个表单中复用的密码规则
+func Rules(fns ...func(*Valuer)) func(*Valuer) {
+	return func(v *Valuer) {
+		v.Apply(fns...)
+	}
+}
+
+// Apply 按顺序将可复用的验证规则添加到当前验证器
+func (v *Valuer) Apply(fns ...func(*Valuer)) *Valuer {
+	for _, fn := range fns {
+		if fn != nil {
+			fn(v)
+		}
+	}
+	return v
+}
+
+// WhenValue 与 When 类似，但条件在执行验证时才根据值进行判断，
+// pred 返回 true 时才执行 then 添加的验证规则，如：值以 http 开头时验证其是否为有效的链接
+func (v *Valuer) WhenValue(pred func(val any) bool, then func(*Valuer)) *Valuer {
+	if pred == nil || then == nil {
+		return v
+	}
+	v.describe("when", nil)
+	return v.addContextRule(func(ctx context.Context, a any) error {
+		if !pred(a) {
+			return nil
+		}
+		x := v.sub("when")
+		then(x)
+		return x.ValidateContext(ctx)
+	})
+}
+
+// Unless 与 When 相反，条件不成立时才执行 then 添加的验证规则
+func (v *Valuer) Unless(condition bool, then func(*Valuer)) *Valuer {
+	if !condition && then != nil {
+		v.describe("unless", nil)
+		v.addContextRule(func(ctx context.Context, a any) error {
+			x := v.sub("unless")
 			then(x)
-			return x.Validate()
+			return x.ValidateContext(ctx)
 		})
 	}
 	return v
 }
 
+// UnlessValue 与 WhenValue 相反，pred 返回 false 时才执行 then 添加的验证规则
+func (v *Valuer) UnlessValue(pred func(val any) bool, then func(*Valuer)) *Valuer {
+	if pred == nil || then == nil {
+		return v
+	}
+	v.describe("unless", nil)
+	return v.addContextRule(func(ctx context.Context, a any) error {
+		if pred(a) {
+			return nil
+		}
+		x := v.sub("unless")
+		then(x)
+		return x.ValidateContext(ctx)
+	})
+}
+
+// Not 对 build 构建的验证规则取反，嵌套验证通过时返回 not 错误，未通过时视为通过（忽略嵌套错误），
+// 如：Not(func(x *Valuer) { x.IsEmail() }) 表示值不能是电子邮箱地址
+func (v *Valuer) Not(build func(*Valuer), options ...ErrorOption) *Valuer {
+	v.describe("not", options)
+	return v.addContextRule(func(ctx context.Context, a any) error {
+		x := v.sub("")
+		x.all = false
+		build(x)
+		if err := x.ValidateContext(ctx); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			return nil
+		}
+		return v.newError("not", options)
+	})
+}
+
+// Recurse 若值实现了 Validatable 接口（包括指针接收者），则调用其 Validate 方法，
+// 返回的错误的字段名将以当前字段名为前缀，如：address.city
+func (v *Valuer) Recurse() *Valuer {
+	v.describe("recurse", nil)
+	return v.addRule(func(val any) error {
+		x, ok := v.value.(Validatable)
+		if !ok {
+			x, ok = val.(Validatable)
+		}
+		if !ok {
+			ptr := reflect.New(reflect.TypeOf(val))
+			ptr.Elem().Set(reflect.ValueOf(val))
+			x, ok = ptr.Interface().(Validatable)
+		}
+		if !ok {
+			return nil
+		}
+		return v.nest(x.Validate())
+	})
+}
+
+// nest 将嵌套验证返回的错误归属到当前字段下
+func (v *Valuer) nest(err error) error {
+	return v.nestAt(v.field, err)
+}
+
+// nestAt 将嵌套验证返回的错误归属到路径 path 下，
+// 未设置字段名或字段名与当前字段相同的错误，其字段名将被设置为 path，
+// 其它错误的字段名将以 path 为前缀，如：address.city
+func (v *Valuer) nestAt(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	errs := &Errors{}
+	errs.Add(err)
+	for i, e := range errs.errors {
+		if e.field == "" && e.code == "" {
+			e = v.mistake(e.error)
+		} else {
+			// 嵌套验证返回的错误可能被共享（如：缓存的错误），修改其副本
+			e = e.clone()
+		}
+		errs.errors[i] = e
+		if e.field == "" || e.field == v.field {
+			e.field = path
+			if e.label == "" {
+				e.label = v.label
+			}
+		} else {
+			e.field = path + fieldSeparator() + e.field
+		}
+	}
+	return errs
+}
+
+// Match 根据（经过 Transform 转换后的）值选择分支进行验证，分支验证器继承当前验证器的脱敏设置
 func (v *Valuer) Match(handle func(m *Matcher)) *Valuer {
-	return v.addRule(func(a any) error {
-		m := Match(v.value, v.field, v.label)
+	return v.addContextRule(func(ctx context.Context, a any) error {
+		m := Match(a, v.field, v.label)
+		m.ctx = v.ctx
+		m.all = v.all
+		m.redact = v.redact
 		handle(m)
-		return m.Validate()
+		return m.ValidateContext(ctx)
 	})
 }
 
 func (v *Valuer) Typeof(kind reflect.Kind, options ...ErrorOption) *Valuer {
+	options = merge(options, ErrorParam("kind", kind))
+	v.describe("typeof", options)
 	return v.addRule(func(val any) error {
 		if reflect.TypeOf(val).Kind() != kind {
-			options = merge(options, ErrorParam("kind", kind))
 			return v.newError("typeof", options)
 		}
 		return nil
@@ -208,6 +752,16 @@ func (v *Valuer) IsPhoneNumber(options ...ErrorOption) *Valuer {
 	return v.string("is_phone_number", is.PhoneNumber, options)
 }
 
+// IsPhoneNumberOf 值是否为指定地区（如：CN、US）的电话号码，支持带有国际区号（+86、0086）、
+// 长途前缀及分隔符的号码，可以配合 NormalizePhoneNumber 获取 E.164 格式的号码，地区不受支持时将引发 panic
+func (v *Valuer) IsPhoneNumberOf(region string, options ...ErrorOption) *Valuer {
+	r := mustPhoneRegion(region)
+	return v.string("is_phone_number_of", func(s string) bool {
+		_, ok := nationalNumber(s, r)
+		return ok
+	}, merge(options, ErrorParam("region", strings.ToUpper(region))))
+}
+
 func (v *Valuer) IsURL(options ...ErrorOption) *Valuer {
 	return v.string("is_url", is.URL, options)
 }
@@ -224,6 +778,41 @@ func (v *Valuer) IsSemver(options ...ErrorOption) *Valuer {
 	return v.string("is_semver", is.Semver, options)
 }
 
+// SemverFlag 语义化版本号的附加约束
+type SemverFlag int
+
+const (
+	NoPrerelease SemverFlag = 1 << iota // 不能是预发布版本，如：1.0.0-alpha
+	RequireV                            // 必须以 v 开头，如：v1.0.0
+	ForbidV                             // 不能以 v 开头
+)
+
+// IsSemverOf 值必须是满足附加约束 flags 的语义化版本号，
+// 违反约束时的错误代码分别为 semver_no_prerelease、semver_require_v 和 semver_forbid_v
+func (v *Valuer) IsSemverOf(flags SemverFlag, options ...ErrorOption) *Valuer {
+	v.describe("is_semver", merge(options, ErrorParam("flags", flags)))
+	return v.addRule(func(val any) error {
+		str := toString(val)
+		body, prefixed := strings.CutPrefix(str, "v")
+		if !is.Semver(str) && !is.Semver(body) {
+			return v.newError("is_semver", options)
+		}
+		if flags&RequireV != 0 && !prefixed {
+			return v.newError("semver_require_v", options)
+		}
+		if flags&ForbidV != 0 && prefixed {
+			return v.newError("semver_forbid_v", options)
+		}
+		if flags&NoPrerelease != 0 {
+			version, _, _ := strings.Cut(body, "+")
+			if strings.Contains(version, "-") {
+				return v.newError("semver_no_prerelease", options)
+			}
+		}
+		return nil
+	})
+}
+
 func (v *Valuer) IsJwt(options ...ErrorOption) *Valuer {
 	return v.string("is_jwt", is.JWT, options)
 }
@@ -244,6 +833,20 @@ func (v *Valuer) IsUUID3(options ...ErrorOption) *Valuer {
 	return v.string("is_uuid3", is.UUID3, options)
 }
 
+// IsUUID7 值是否为 V7 版 UUID 字符串（基于时间排序）
+func (v *Valuer) IsUUID7(options ...ErrorOption) *Valuer {
+	return v.string("is_uuid7", func(s string) bool { return isUUIDVersion(s, 7) }, options)
+}
+
+// IsUUIDVersion 值是否为指定版本（1～8）的 UUID 字符串，同时检查标准格式及版本位
+func (v *Valuer) IsUUIDVersion(version int, options ...ErrorOption) *Valuer {
+	return v.string(
+		"is_uuid_version",
+		func(s string) bool { return isUUIDVersion(s, version) },
+		merge(options, ErrorParam("version", version)),
+	)
+}
+
 func (v *Valuer) IsULID(options ...ErrorOption) *Valuer {
 	return v.string("is_ulid", is.ULID, options)
 }
@@ -300,6 +903,29 @@ func (v *Valuer) IsBool(options ...ErrorOption) *Valuer {
 	return v.simple("is_bool", is.Boolean[any], options)
 }
 
+// IsCreditCard 值必须是通过 Luhn 校验的信用卡号，允许包含空格和短横线
+func (v *Valuer) IsCreditCard(options ...ErrorOption) *Valuer {
+	return v.string("is_credit_card", func(s string) bool {
+		return isCreditCard(normalizeCardNumber(s))
+	}, options)
+}
+
+// IsCreditCardOf 值必须是属于指定网络（如：CardVisa）的有效信用卡号
+func (v *Valuer) IsCreditCardOf(networks []string, options ...ErrorOption) *Valuer {
+	return v.string("is_credit_card", func(s string) bool {
+		number := normalizeCardNumber(s)
+		if !isCreditCard(number) {
+			return false
+		}
+		for _, network := range networks {
+			if cardNetworkOf(number, network) {
+				return true
+			}
+		}
+		return false
+	}, merge(options, ErrorParam("networks", strings.Join(networks, ", "))))
+}
+
 func (v *Valuer) IsHexadecimal(options ...ErrorOption) *Valuer {
 	return v.string("is_hexadecimal", is.Hexadecimal, options)
 }
@@ -340,6 +966,27 @@ func (v *Valuer) IsJson(options ...ErrorOption) *Valuer {
 	return v.simple("is_json", is.JSON[any], options)
 }
 
+// MatchesJSONSchema 值（JSON 字符串或可以序列化成 JSON 的值）必须符合 JSON Schema，
+// Schema 只编译一次并被缓存，违规时通过参数 path 和 reason 报告第一个违规位置及原因，
+// 使用前需要通过 SetSchemaCompiler 设置 JSON Schema 的实现，未设置或 Schema 无效时验证返回编译错误
+func (v *Valuer) MatchesJSONSchema(schema string, options ...ErrorOption) *Valuer {
+	v.describe("json_schema", merge(options, ErrorParam("schema", schema)))
+	return v.addRule(func(val any) error {
+		validate, err := compileSchema(schema)
+		if err != nil {
+			return err
+		}
+		doc, ok := toJSONDocument(val)
+		if !ok {
+			return v.newError("is_json", options)
+		}
+		if path, err := validate(doc); err != nil {
+			return v.newError("json_schema", merge(options, ErrorParam("path", path), ErrorParam("reason", err.Error())))
+		}
+		return nil
+	})
+}
+
 func (v *Valuer) IsBase64(options ...ErrorOption) *Valuer {
 	return v.string("is_base64", is.Base64, options)
 }
@@ -360,40 +1007,313 @@ func (v *Valuer) IsDatetime(layout string, options ...ErrorOption) *Valuer {
 	)
 }
 
-func (v *Valuer) IsTimezone(options ...ErrorOption) *Valuer {
-	return v.string("is_timezone", is.Timezone, options)
+// After 按 layout 解析值后，时间必须晚于 t（不包含 t），值也可以是 time.Time
+func (v *Valuer) After(layout string, t time.Time, options ...ErrorOption) *Valuer {
+	return v.datetime(layout, "after", func(x time.Time) bool { return x.After(t) },
+		merge(options, ErrorParam("time", t.Format(layout))))
 }
 
-func (v *Valuer) IsIPv4(options ...ErrorOption) *Valuer {
-	return v.string("is_ipv4", is.IPv4, options)
+// AfterOrEqual 与 After 相同，但包含 t
+func (v *Valuer) AfterOrEqual(layout string, t time.Time, options ...ErrorOption) *Valuer {
+	return v.datetime(layout, "after_or_equal", func(x time.Time) bool { return !x.Before(t) },
+		merge(options, ErrorParam("time", t.Format(layout))))
 }
 
-func (v *Valuer) IsIPv6(options ...ErrorOption) *Valuer {
-	return v.string("is_ipv6", is.IPv6, options)
+// Before 按 layout 解析值后，时间必须早于 t（不包含 t），如：生日必须早于今天
+func (v *Valuer) Before(layout string, t time.Time, options ...ErrorOption) *Valuer {
+	return v.datetime(layout, "before", func(x time.Time) bool { return x.Before(t) },
+		merge(options, ErrorParam("time", t.Format(layout))))
 }
 
-func (v *Valuer) IsIP(options ...ErrorOption) *Valuer {
-	return v.string("is_ip", is.IP, options)
+// BeforeOrEqual 与 Before 相同，但包含 t
+func (v *Valuer) BeforeOrEqual(layout string, t time.Time, options ...ErrorOption) *Valuer {
+	return v.datetime(layout, "before_or_equal", func(x time.Time) bool { return !x.After(t) },
+		merge(options, ErrorParam("time", t.Format(layout))))
 }
 
-func (v *Valuer) IsMAC(options ...ErrorOption) *Valuer {
-	return v.string("is_mac", is.MAC, options)
+// DateBetween 按 layout 解析值后，时间必须在 start 与 end 之间（包含边界）
+func (v *Valuer) DateBetween(layout string, start, end time.Time, options ...ErrorOption) *Valuer {
+	return v.datetime(layout, "date_between", func(x time.Time) bool { return !x.Before(start) && !x.After(end) },
+		merge(options, ErrorParam("start", start.Format(layout)), ErrorParam("end", end.Format(layout))))
 }
 
-func (v *Valuer) IsFile(options ...ErrorOption) *Valuer {
-	return v.simple("is_file", is.File, options)
+// datetime 添加按 layout 解析值后再比较的规则，无法解析时返回 is_datetime 错误
+func (v *Valuer) datetime(layout, code string, check func(time.Time) bool, options []ErrorOption) *Valuer {
+	v.describe(code, options)
+	return v.addRule(func(val any) error {
+		t, ok := val.(time.Time)
+		if !ok {
+			var err error
+			if t, err = time.Parse(layout, toString(val)); err != nil {
+				return v.newError("is_datetime", merge(options, ErrorParam("layout", layout)))
+			}
+		}
+		if !check(t) {
+			return v.newError(code, options)
+		}
+		return nil
+	})
 }
 
-func (v *Valuer) IsDir(options ...ErrorOption) *Valuer {
-	return v.simple("is_file", is.Dir, options)
+// IsDuration 值是否为有效的时间段，如：30s、5m、1h30m，参考 time.ParseDuration
+func (v *Valuer) IsDuration(options ...ErrorOption) *Valuer {
+	return v.simple("is_duration", func(a any) bool {
+		_, ok := toDuration(a)
+		return ok
+	}, options)
 }
 
-func (v *Valuer) IsLower(options ...ErrorOption) *Valuer {
-	return v.string("is_lower", is.Lowercase, options)
+// DurationBetween 值表示的时间段必须在 min 与 max 之间（包含边界）
+func (v *Valuer) DurationBetween(min, max time.Duration, options ...ErrorOption) *Valuer {
+	return v.duration("duration_between", func(d time.Duration) bool { return d >= min && d <= max },
+		merge(options, ErrorParam("min", min), ErrorParam("max", max)))
 }
 
-func (v *Valuer) IsUpper(options ...ErrorOption) *Valuer {
-	return v.string("is_upper", is.Uppercase, options)
+// MinDuration 值表示的时间段必须大于或等于 min
+func (v *Valuer) MinDuration(min time.Duration, options ...ErrorOption) *Valuer {
+	return v.duration("min_duration", func(d time.Duration) bool { return d >= min },
+		merge(options, ErrorParam("min", min)))
+}
+
+// MaxDuration 值表示的时间段必须小于或等于 max
+func (v *Valuer) MaxDuration(max time.Duration, options ...ErrorOption) *Valuer {
+	return v.duration("max_duration", func(d time.Duration) bool { return d <= max },
+		merge(options, ErrorParam("max", max)))
+}
+
+// duration 添加将值解析成时间段后再比较的规则，无法解析时返回 is_duration 错误
+func (v *Valuer) duration(code string, check func(time.Duration) bool, options []ErrorOption) *Valuer {
+	v.describe(code, options)
+	return v.addRule(func(val any) error {
+		d, ok := toDuration(val)
+		if !ok {
+			return v.newError("is_duration", options)
+		}
+		if !check(d) {
+			return v.newError(code, merge(options, ErrorParam("duration", d)))
+		}
+		return nil
+	})
+}
+
+// toDuration 将 time.Duration 或时间段字符串转换成 time.Duration
+func toDuration(val any) (time.Duration, bool) {
+	switch x := val.(type) {
+	case time.Duration:
+		return x, true
+	case string:
+		d, err := time.ParseDuration(strings.TrimSpace(x))
+		return d, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// IsDatetimeAny 值必须符合 layouts 中任意一种时间格式
+func (v *Valuer) IsDatetimeAny(layouts []string, options ...ErrorOption) *Valuer {
+	return v.simple(
+		"is_datetime_any",
+		func(a any) bool {
+			str := toString(a)
+			for _, layout := range layouts {
+				if is.Datetime(str, layout) {
+					return true
+				}
+			}
+			return false
+		},
+		merge(options, ErrorParam("layouts", strings.Join(layouts, ", "))),
+	)
+}
+
+func (v *Valuer) IsTimezone(options ...ErrorOption) *Valuer {
+	return v.string("is_timezone", is.Timezone, options)
+}
+
+func (v *Valuer) IsIPv4(options ...ErrorOption) *Valuer {
+	return v.string("is_ipv4", is.IPv4, options)
+}
+
+func (v *Valuer) IsIPv6(options ...ErrorOption) *Valuer {
+	return v.string("is_ipv6", is.IPv6, options)
+}
+
+func (v *Valuer) IsIP(options ...ErrorOption) *Valuer {
+	return v.string("is_ip", is.IP, options)
+}
+
+// IPVersion 值必须是指定版本（4 或 6）的 IP 地址，版本不符时报告实际的版本
+func (v *Valuer) IPVersion(version int, options ...ErrorOption) *Valuer {
+	v.describe("ip_version", merge(options, ErrorParam("version", version)))
+	return v.addRule(func(val any) error {
+		ip := net.ParseIP(toString(val))
+		if ip == nil {
+			return v.newError("is_ip", options)
+		}
+		actual := 6
+		if ip.To4() != nil {
+			actual = 4
+		}
+		if actual == version {
+			return nil
+		}
+		return v.newError("ip_version", merge(options, ErrorParam("version", version), ErrorParam("actual", actual)))
+	})
+}
+
+// IsCIDR 值是否为 CIDR 形式的 IPv4 或 IPv6 网段，如：10.0.0.0/8、2001:db8::/32
+func (v *Valuer) IsCIDR(options ...ErrorOption) *Valuer {
+	return v.string("is_cidr", func(s string) bool {
+		_, _, err := net.ParseCIDR(s)
+		return err == nil
+	}, options)
+}
+
+// IsIPInCIDR 值必须是位于网段 cidr 内的 IP 地址，cidr 无效时将引发 panic
+func (v *Valuer) IsIPInCIDR(cidr string, options ...ErrorOption) *Valuer {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(fmt.Errorf("invalid cidr %q: %w", cidr, err))
+	}
+	return v.string("is_ip_in_cidr", func(s string) bool {
+		ip := net.ParseIP(s)
+		return ip != nil && network.Contains(ip)
+	}, merge(options, ErrorParam("cidr", cidr)))
+}
+
+// IsCountryCode 值是否为 ISO 3166-1 alpha-2 国家/地区代码（不区分大小写），如：CN、US
+func (v *Valuer) IsCountryCode(options ...ErrorOption) *Valuer {
+	return v.string("is_country_code", isISOCode(&countryCodes), options)
+}
+
+// IsCountryCode3 值是否为 ISO 3166-1 alpha-3 国家/地区代码（不区分大小写），如：CHN、USA
+func (v *Valuer) IsCountryCode3(options ...ErrorOption) *Valuer {
+	return v.string("is_country_code3", isISOCode(&countryCodes3), options)
+}
+
+// IsCurrencyCode 值是否为 ISO 4217 货币代码（不区分大小写），如：CNY、USD
+func (v *Valuer) IsCurrencyCode(options ...ErrorOption) *Valuer {
+	return v.string("is_currency_code", isISOCode(&currencyCodes), options)
+}
+
+// IsLanguageCode 值是否为 ISO 639-1 语言代码（不区分大小写），如：zh、en
+func (v *Valuer) IsLanguageCode(options ...ErrorOption) *Valuer {
+	return v.string("is_language_code", isISOCode(&languageCodes), options)
+}
+
+// IsHostname 值是否为有效的主机名，如：localhost、db-01.internal
+func (v *Valuer) IsHostname(options ...ErrorOption) *Valuer {
+	return v.string("is_hostname", isHostname, options)
+}
+
+// IsFQDN 值是否为完全限定域名，如：api.example.com
+func (v *Valuer) IsFQDN(options ...ErrorOption) *Valuer {
+	return v.string("is_fqdn", isFQDN, options)
+}
+
+// IsHostPort 值是否为 host:port 形式的地址，如：example.com:8080、[::1]:443
+func (v *Valuer) IsHostPort(options ...ErrorOption) *Valuer {
+	return v.string("is_host_port", isHostPort, options)
+}
+
+func (v *Valuer) IsMAC(options ...ErrorOption) *Valuer {
+	return v.string("is_mac", is.MAC, options)
+}
+
+// IsMimeType 值是否为有效的 MIME 类型（type/subtype，可以带有参数），如：image/png、text/html; charset=utf-8
+func (v *Valuer) IsMimeType(options ...ErrorOption) *Valuer {
+	return v.string("is_mime_type", func(s string) bool {
+		_, ok := parseMimeType(s)
+		return ok
+	}, options)
+}
+
+// MimeTypeOf 值必须是 allowed 中的一种 MIME 类型（忽略大小写及参数），支持 image/* 形式的通配符
+func (v *Valuer) MimeTypeOf(allowed []string, options ...ErrorOption) *Valuer {
+	return v.string("mime_type_of", func(s string) bool {
+		mediatype, ok := parseMimeType(s)
+		if !ok {
+			return false
+		}
+		for _, pattern := range allowed {
+			pattern = strings.ToLower(strings.TrimSpace(pattern))
+			if pattern == mediatype || pattern == "*/*" {
+				return true
+			}
+			if prefix, found := strings.CutSuffix(pattern, "/*"); found && strings.HasPrefix(mediatype, prefix+"/") {
+				return true
+			}
+		}
+		return false
+	}, merge(options, ErrorParam("types", strings.Join(allowed, ", "))))
+}
+
+// parseMimeType 解析 MIME 类型，返回不带参数的小写形式
+func parseMimeType(s string) (string, bool) {
+	mediatype, _, err := mime.ParseMediaType(s)
+	if err != nil {
+		return "", false
+	}
+	typ, subtype, ok := strings.Cut(mediatype, "/")
+	return mediatype, ok && typ != "" && subtype != ""
+}
+
+func (v *Valuer) IsFile(options ...ErrorOption) *Valuer {
+	return v.simple("is_file", is.File, options)
+}
+
+// FileMaxSize 值是文件路径，文件大小必须小于或等于 bytes 字节，文件不存在时返回与 IsFile 相同的错误
+func (v *Valuer) FileMaxSize(bytes int64, options ...ErrorOption) *Valuer {
+	v.describe("file_max_size", merge(options, ErrorParam("max", bytes)))
+	return v.addRule(func(val any) error {
+		info, err := os.Stat(toString(val))
+		if err != nil || info.IsDir() {
+			return v.newError("is_file", options)
+		}
+		if size := info.Size(); size > bytes {
+			return v.newError("file_max_size", merge(options, ErrorParam("max", bytes), ErrorParam("size", size)))
+		}
+		return nil
+	})
+}
+
+// FileExt 值是文件路径，其扩展名必须是 exts 中的一种（不区分大小写，可以省略前导的点），如：[]string{"jpg", ".png"}
+func (v *Valuer) FileExt(exts []string, options ...ErrorOption) *Valuer {
+	return v.string("file_ext", func(s string) bool {
+		ext := strings.TrimPrefix(filepath.Ext(s), ".")
+		if ext == "" {
+			return false
+		}
+		for _, allowed := range exts {
+			if strings.EqualFold(ext, strings.TrimPrefix(allowed, ".")) {
+				return true
+			}
+		}
+		return false
+	}, merge(options, ErrorParam("exts", strings.Join(exts, ", "))))
+}
+
+func (v *Valuer) IsDir(options ...ErrorOption) *Valuer {
+	return v.simple("is_file", is.Dir, options)
+}
+
+// IsSlug 值是否为 URL 友好的标识，只能包含小写字母、数字和连字符，
+// 且连字符不能出现在首尾或连续出现，如：hello-world-123
+func (v *Valuer) IsSlug(options ...ErrorOption) *Valuer {
+	return v.string("is_slug", func(s string) bool { return isSlug(s, false) }, options)
+}
+
+// IsUnicodeSlug 同 IsSlug，但允许使用 Unicode 小写字母（及中文等无大小写之分的文字）和数字
+func (v *Valuer) IsUnicodeSlug(options ...ErrorOption) *Valuer {
+	return v.string("is_slug", func(s string) bool { return isSlug(s, true) }, options)
+}
+
+func (v *Valuer) IsLower(options ...ErrorOption) *Valuer {
+	return v.string("is_lower", is.Lowercase, options)
+}
+
+func (v *Valuer) IsUpper(options ...ErrorOption) *Valuer {
+	return v.string("is_upper", is.Uppercase, options)
 }
 
 func (v *Valuer) IsLabel(options ...ErrorOption) *Valuer {
@@ -404,6 +1324,21 @@ func (v *Valuer) IsLabel(options ...ErrorOption) *Valuer {
 	)
 }
 
+// IsGoIdentifier 值必须是有效的 Go 标识符（不能是关键字）
+func (v *Valuer) IsGoIdentifier(options ...ErrorOption) *Valuer {
+	return v.string("is_go_identifier", token.IsIdentifier, options)
+}
+
+// IsEnvVarName 值必须是有效的环境变量名称，即 [A-Z_][A-Z0-9_]*
+func (v *Valuer) IsEnvVarName(options ...ErrorOption) *Valuer {
+	return v.string("is_env_var_name", func(s string) bool { return isEnvVarName(s, false) }, options)
+}
+
+// IsEnvVarNameIgnoreCase 同 IsEnvVarName，但允许使用小写字母
+func (v *Valuer) IsEnvVarNameIgnoreCase(options ...ErrorOption) *Valuer {
+	return v.string("is_env_var_name", func(s string) bool { return isEnvVarName(s, true) }, options)
+}
+
 func (v *Valuer) Contains(substr string, options ...ErrorOption) *Valuer {
 	return v.simple(
 		"contains",
@@ -492,6 +1427,109 @@ func (v *Valuer) OneOf(items []any, options ...ErrorOption) *Valuer {
 	)
 }
 
+// NotOneOf 值不能是 items 中的任何一项，如：保留的用户名
+func (v *Valuer) NotOneOf(items []any, options ...ErrorOption) *Valuer {
+	return v.simple(
+		"not_one_of",
+		func(value any) bool { return !is.OneOf(value, items) },
+		merge(options, ErrorParam("items", items)),
+	)
+}
+
+// NotOneOfFold 同 NotOneOf，但以不区分大小写的方式比较字符串
+func (v *Valuer) NotOneOfFold(items []string, options ...ErrorOption) *Valuer {
+	return v.string(
+		"not_one_of",
+		func(s string) bool {
+			for _, item := range items {
+				if strings.EqualFold(s, item) {
+					return false
+				}
+			}
+			return true
+		},
+		merge(options, ErrorParam("items", items)),
+	)
+}
+
+// OneOfFunc 值必须是动态获取的集合中的一项，
+// 若 fetch 返回的第二个值为 false，表示集合不可用，跳过该验证
+func (v *Valuer) OneOfFunc(fetch func() ([]any, bool), options ...ErrorOption) *Valuer {
+	v.describe("one_of", options)
+	return v.addRule(func(val any) error {
+		items, ok := fetch()
+		if !ok || is.OneOf(val, items) {
+			return nil
+		}
+		return v.newError("one_of", merge(options, ErrorParam("items", items)))
+	})
+}
+
+// InEnum 值必须是枚举类型的有效值之一，enum 为枚举类型的任意值，
+// 该类型必须实现返回全部有效值切片的 Values 方法（如：代码生成的枚举类型）
+func (v *Valuer) InEnum(enum any, options ...ErrorOption) *Valuer {
+	items := enumValues(enum)
+	return v.simple(
+		"one_of",
+		func(value any) bool { return is.OneOf(value, items) },
+		merge(options, ErrorParam("items", items)),
+	)
+}
+
+// enumValues 通过反射调用枚举类型的 Values 方法获取全部有效值
+func enumValues(enum any) []any {
+	rv := reflect.ValueOf(enum)
+	if !rv.IsValid() {
+		panic(fmt.Errorf("enum must not be nil"))
+	}
+	method := rv.MethodByName("Values")
+	if !method.IsValid() && rv.Kind() != reflect.Ptr {
+		ptr := reflect.New(rv.Type())
+		ptr.Elem().Set(rv)
+		method = ptr.MethodByName("Values")
+	}
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		panic(fmt.Errorf("%T must have a Values method without arguments returning a slice", enum))
+	}
+	values := method.Call(nil)[0]
+	if values.Kind() != reflect.Slice && values.Kind() != reflect.Array {
+		panic(fmt.Errorf("%T.Values must return a slice, got %s", enum, values.Type()))
+	}
+	items := make([]any, values.Len())
+	for i := range items {
+		items[i] = values.Index(i).Interface()
+	}
+	return items
+}
+
+// Matches 值必须匹配正则表达式 pattern，表达式只编译一次，无效时触发 panic
+func (v *Valuer) Matches(pattern string, options ...ErrorOption) *Valuer {
+	return v.MatchesRegexp(compileRegexp(pattern), options...)
+}
+
+// MatchesRegexp 值必须匹配已编译的正则表达式
+func (v *Valuer) MatchesRegexp(re *regexp.Regexp, options ...ErrorOption) *Valuer {
+	return v.string("matches", re.MatchString, merge(options, ErrorParam("pattern", re.String())))
+}
+
+// MatchesAnyPattern 值必须匹配 patterns 中任意一个通配符模式（如："*.example.com"），
+// 模式语法参考 path.Match，模式无效时触发 panic
+func (v *Valuer) MatchesAnyPattern(patterns []string, options ...ErrorOption) *Valuer {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic(fmt.Errorf("invalid pattern %q: %w", pattern, err))
+		}
+	}
+	return v.string("matches_any_pattern", func(s string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, s); ok {
+				return true
+			}
+		}
+		return false
+	}, merge(options, ErrorParam("patterns", strings.Join(patterns, ", "))))
+}
+
 func (v *Valuer) NotEmpty(options ...ErrorOption) *Valuer {
 	return v.simple("not_empty", is.NotEmpty[any], options)
 }
@@ -520,6 +1558,81 @@ func (v *Valuer) MaxLength(max int, options ...ErrorOption) *Valuer {
 	)
 }
 
+// LengthFunc 值的长度必须在根据值计算得到的范围之内，如：长度取决于类型代码
+func (v *Valuer) LengthFunc(fn func(val any) (min, max int), options ...ErrorOption) *Valuer {
+	v.describe("length_between", options)
+	return v.addRule(func(val any) error {
+		min, max := fn(val)
+		if is.LengthBetween(val, min, max) {
+			return nil
+		}
+		return v.newError("length_between", merge(options, ErrorParam("min", min), ErrorParam("max", max)))
+	})
+}
+
+// MinBytes 值的字节数必须大于或等于 min（与字符长度不同，适用于存储限制）
+func (v *Valuer) MinBytes(min int, options ...ErrorOption) *Valuer {
+	v.describe("min_bytes", merge(options, ErrorParam("min", min)))
+	return v.addRule(func(val any) error {
+		if size := len(toString(val)); size < min {
+			return v.newError("min_bytes", merge(options, ErrorParam("min", min), ErrorParam("size", size)))
+		}
+		return nil
+	})
+}
+
+// MaxBytes 值的字节数必须小于或等于 max（与字符长度不同，适用于存储限制）
+func (v *Valuer) MaxBytes(max int, options ...ErrorOption) *Valuer {
+	v.describe("max_bytes", merge(options, ErrorParam("max", max)))
+	return v.addRule(func(val any) error {
+		if size := len(toString(val)); size > max {
+			return v.newError("max_bytes", merge(options, ErrorParam("max", max), ErrorParam("size", size)))
+		}
+		return nil
+	})
+}
+
+// IsByteSize 值是否为有效的字节大小，如：1024、10MB、1.5GiB
+func (v *Valuer) IsByteSize(options ...ErrorOption) *Valuer {
+	return v.simple("is_byte_size", func(a any) bool {
+		_, ok := toByteSize(a)
+		return ok
+	}, options)
+}
+
+// MaxByteSize 值表示的字节大小必须小于或等于 limit，值与 limit 均可以是 10MB 形式的字符串，
+// 值也可以是表示字节数的整数，limit 无效时将引发 panic
+func (v *Valuer) MaxByteSize(limit string, options ...ErrorOption) *Valuer {
+	max := mustParseByteSize(limit)
+	v.describe("max_byte_size", merge(options, ErrorParam("max", limit), ErrorParam("bytes", max)))
+	return v.addRule(func(val any) error {
+		size, ok := toByteSize(val)
+		if !ok {
+			return v.newError("is_byte_size", options)
+		}
+		if size > max {
+			return v.newError("max_byte_size", merge(options, ErrorParam("max", limit), ErrorParam("bytes", max), ErrorParam("size", size)))
+		}
+		return nil
+	})
+}
+
+// MinByteSize 值表示的字节大小必须大于或等于 limit，limit 无效时将引发 panic
+func (v *Valuer) MinByteSize(limit string, options ...ErrorOption) *Valuer {
+	min := mustParseByteSize(limit)
+	v.describe("min_byte_size", merge(options, ErrorParam("min", limit), ErrorParam("bytes", min)))
+	return v.addRule(func(val any) error {
+		size, ok := toByteSize(val)
+		if !ok {
+			return v.newError("is_byte_size", options)
+		}
+		if size < min {
+			return v.newError("min_byte_size", merge(options, ErrorParam("min", limit), ErrorParam("bytes", min), ErrorParam("size", size)))
+		}
+		return nil
+	})
+}
+
 func (v *Valuer) LengthBetween(min, max int, options ...ErrorOption) *Valuer {
 	return v.simple(
 		"length_between",
@@ -528,6 +1641,27 @@ func (v *Valuer) LengthBetween(min, max int, options ...ErrorOption) *Valuer {
 	)
 }
 
+// CountBetween 数组、切片或字典的元素个数必须在 min 与 max 之间（包含边界），
+// 与按字符数计算字符串长度的 LengthBetween 不同，该规则始终使用 reflect.Value.Len，
+// 如 []byte 按字节数计算；值不是数组、切片或字典时返回 count 错误，
+// 元素个数不满足要求时返回 count_between 错误，并通过参数 count 返回实际的元素个数
+func (v *Valuer) CountBetween(min, max int, options ...ErrorOption) *Valuer {
+	options = merge(options, ErrorParam("min", min), ErrorParam("max", max))
+	v.describe("count_between", options)
+	return v.addRule(func(val any) error {
+		rv := indirect(reflect.ValueOf(val))
+		switch rv.Kind() {
+		case reflect.Array, reflect.Slice, reflect.Map:
+		default:
+			return v.newError("count", options)
+		}
+		if n := rv.Len(); n < min || n > max {
+			return v.newError("count_between", merge(options, ErrorParam("count", n)))
+		}
+		return nil
+	})
+}
+
 func (v *Valuer) GreaterThan(min any, options ...ErrorOption) *Valuer {
 	return v.simple(
 		"greater_than",
@@ -552,6 +1686,18 @@ func (v *Valuer) Equal(another any, options ...ErrorOption) *Valuer {
 	)
 }
 
+// EqualFunc 值必须等于根据值计算得到的期望值，如：校验位
+func (v *Valuer) EqualFunc(expected func(val any) any, options ...ErrorOption) *Valuer {
+	v.describe("equal", options)
+	return v.addRule(func(val any) error {
+		another := expected(val)
+		if is.Equal(val, another) {
+			return nil
+		}
+		return v.newError("equal", merge(options, ErrorParam("another", another)))
+	})
+}
+
 func (v *Valuer) NotEqual(another any, options ...ErrorOption) *Valuer {
 	return v.simple(
 		"not_equal",
@@ -560,6 +1706,70 @@ func (v *Valuer) NotEqual(another any, options ...ErrorOption) *Valuer {
 	)
 }
 
+// Immutable 值不允许被修改，即必须与原值 old 相等
+func (v *Valuer) Immutable(old any, options ...ErrorOption) *Valuer {
+	v.describe("immutable", options)
+	return v.addRule(func(val any) error {
+		if is.Equal(val, old) {
+			return nil
+		}
+		return v.newError("immutable", merge(options, v.diff(old, val)...))
+	})
+}
+
+// Changed 值必须被修改，即不能与原值 old 相等
+func (v *Valuer) Changed(old any, options ...ErrorOption) *Valuer {
+	v.describe("changed", options)
+	return v.addRule(func(val any) error {
+		if is.NotEqual(val, old) {
+			return nil
+		}
+		return v.newError("changed", merge(options, v.diff(old, val)...))
+	})
+}
+
+// diff 返回包含新旧值的错误参数，值需要脱敏时使用 "***" 代替
+func (v *Valuer) diff(old, new any) []ErrorOption {
+	if v.redact {
+		old, new = redactedValue, redactedValue
+	}
+	return []ErrorOption{ErrorParam("old", old), ErrorParam("new", new)}
+}
+
+// OtherLabel 设置跨字段比较规则中另一个字段的标签，默认使用其字段名
+func OtherLabel(label string) ErrorOption {
+	return ErrorParam("other_label", label)
+}
+
+// compareField 与另一个字段的值进行比较，另一个字段的值（如：密码）不会出现在错误参数中
+func (v *Valuer) compareField(code string, other any, field string, check func(a, b any) bool, options []ErrorOption) *Valuer {
+	return v.simple(
+		code,
+		func(a any) bool { return check(a, other) },
+		merge(options, ErrorParam("other_field", field), ErrorParam("other_label", field)),
+	)
+}
+
+// EqualField 值必须等于另一个字段的值，如：确认密码必须与密码相同
+func (v *Valuer) EqualField(other any, field string, options ...ErrorOption) *Valuer {
+	return v.compareField("equal_field", other, field, is.Equal, options)
+}
+
+// NotEqualField 值不能等于另一个字段的值
+func (v *Valuer) NotEqualField(other any, field string, options ...ErrorOption) *Valuer {
+	return v.compareField("not_equal_field", other, field, is.NotEqual, options)
+}
+
+// GreaterThanField 值必须大于另一个字段的值
+func (v *Valuer) GreaterThanField(other any, field string, options ...ErrorOption) *Valuer {
+	return v.compareField("greater_than_field", other, field, is.GreaterThan, options)
+}
+
+// LessThanField 值必须小于另一个字段的值
+func (v *Valuer) LessThanField(other any, field string, options ...ErrorOption) *Valuer {
+	return v.compareField("less_than_field", other, field, is.LessThan, options)
+}
+
 func (v *Valuer) LessEqualThan(max any, options ...ErrorOption) *Valuer {
 	return v.simple(
 		"less_equal_than",
@@ -592,86 +1802,723 @@ func (v *Valuer) NotBetween(min, max any, options ...ErrorOption) *Valuer {
 	)
 }
 
-type Item struct {
-	Key   any
-	Index int
-	Value any
+// NumericBetween 将值（包括数值字符串，如表单输入）转换成数值后，判断是否在 min 与 max 之间（包含边界），
+// 无法转换的值（如："1,000"）返回 numeric 错误
+func (v *Valuer) NumericBetween(min, max float64, options ...ErrorOption) *Valuer {
+	return v.numeric(
+		"between",
+		func(f float64) bool { return f >= min && f <= max },
+		merge(options, ErrorParam("min", min), ErrorParam("max", max)),
+	)
 }
 
-func (v *Valuer) itemize(handle func(item *Item) any, every bool, options []ErrorOption) *Valuer {
-	check := func(item *Item) (bool, error) {
-		res := handle(item)
-		if x, ok := res.(Validatable); ok {
-			if err := x.Validate(); res != nil {
-				return false, err
-			} else {
-				return !every, nil
-			}
-		}
-		if res == true || res == nil {
-			if !every {
-				// some 成功
-				return true, nil
-			}
-			return false, nil
-		}
-		if res == false && every {
-			if every {
-				// every 失败
-				return false, v.newError("every", options)
-			}
-			return false, nil
-		}
-		if err, ok := res.(error); ok {
-			// 自定义错误
-			return false, v.mistake(err, options...)
-		}
-		panic(fmt.Errorf("expect a bool, a Validatable or a error, got %+v", res))
-	}
+// NumericMin 将值转换成数值后，判断是否大于或等于 min
+func (v *Valuer) NumericMin(min float64, options ...ErrorOption) *Valuer {
+	return v.numeric(
+		"greater_equal_than",
+		func(f float64) bool { return f >= min },
+		merge(options, ErrorParam("min", min)),
+	)
+}
 
-	v.addRule(func(a any) error {
-		rv := reflect.Indirect(reflect.ValueOf(a))
-		rt := rv.Type()
-		switch k := rt.Kind(); k {
-		case reflect.Array, reflect.Slice:
+// NumericMax 将值转换成数值后，判断是否小于或等于 max
+func (v *Valuer) NumericMax(max float64, options ...ErrorOption) *Valuer {
+	return v.numeric(
+		"less_equal_than",
+		func(f float64) bool { return f <= max },
+		merge(options, ErrorParam("max", max)),
+	)
+}
+
+// IsPositive 值必须是正数（不包括 0），支持数值字符串
+func (v *Valuer) IsPositive(options ...ErrorOption) *Valuer {
+	return v.numeric("positive", func(f float64) bool { return f > 0 }, options)
+}
+
+// IsNegative 值必须是负数（不包括 0），支持数值字符串
+func (v *Valuer) IsNegative(options ...ErrorOption) *Valuer {
+	return v.numeric("negative", func(f float64) bool { return f < 0 }, options)
+}
+
+// IsNonNegative 值必须是非负数（包括 0），支持数值字符串
+func (v *Valuer) IsNonNegative(options ...ErrorOption) *Valuer {
+	return v.numeric("non_negative", func(f float64) bool { return f >= 0 }, options)
+}
+
+// IsNonPositive 值必须是非正数（包括 0），支持数值字符串
+func (v *Valuer) IsNonPositive(options ...ErrorOption) *Valuer {
+	return v.numeric("non_positive", func(f float64) bool { return f <= 0 }, options)
+}
+
+// MultipleOf 值必须是 divisor 的整数倍，如：以分为单位的价格、按包装数量销售的商品，
+// 整数值与整数除数之间精确计算，浮点数允许微小的误差，divisor 为 0 时将引发 panic
+func (v *Valuer) MultipleOf(divisor float64, options ...ErrorOption) *Valuer {
+	if divisor == 0 {
+		panic("divisor must not be zero")
+	}
+	options = merge(options, ErrorParam("divisor", divisor))
+	v.describe("multiple_of", options)
+	return v.addRule(func(val any) error {
+		if isMultipleOf(val, divisor) {
+			return nil
+		}
+		if f, ok := toFloat(val); !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+			return v.newError("numeric", options)
+		}
+		return v.newError("multiple_of", options)
+	})
+}
+
+// isMultipleOf 判断数值（或数值字符串）是否为 divisor 的整数倍
+func isMultipleOf(val any, divisor float64) bool {
+	if divisor == math.Trunc(divisor) && math.Abs(divisor) <= 1<<53 {
+		d := int64(divisor)
+		rv := reflect.Indirect(reflect.ValueOf(val))
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int()%d == 0
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if d < 0 {
+				d = -d
+			}
+			return rv.Uint()%uint64(d) == 0
+		}
+	}
+	f, ok := toFloat(val)
+	if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+		return false
+	}
+	const epsilon = 1e-9
+	d := math.Abs(divisor)
+	r := math.Abs(math.Mod(f, d))
+	tolerance := epsilon * math.Max(1, math.Abs(f/d))
+	return r/d < tolerance || (d-r)/d < tolerance
+}
+
+// numeric 添加将值转换成数值后再比较的规则，无法转换时返回 numeric 错误
+func (v *Valuer) numeric(code string, check func(float64) bool, options []ErrorOption) *Valuer {
+	v.describe(code, options)
+	return v.addRule(func(val any) error {
+		f, ok := toFloat(val)
+		if !ok || math.IsNaN(f) || math.IsInf(f, 0) {
+			return v.newError("numeric", options)
+		}
+		if !check(f) {
+			return v.newError(code, options)
+		}
+		return nil
+	})
+}
+
+// SameSignAs 值与另一个数值的正负号必须一致（同为正数、同为负数或同为零）
+func (v *Valuer) SameSignAs(another any, options ...ErrorOption) *Valuer {
+	v.describe("same_sign", merge(options, ErrorParam("another", another)))
+	return v.addRule(func(val any) error {
+		a, ok1 := toFloat(val)
+		b, ok2 := toFloat(another)
+		if ok1 && ok2 && sign(a) == sign(b) {
+			return nil
+		}
+		return v.newError("same_sign", merge(
+			options,
+			ErrorParam("another", another),
+			ErrorParam("sign", sign(a)),
+			ErrorParam("another_sign", sign(b)),
+		))
+	})
+}
+
+// IsPercentage 值必须是 0 到 100 之间的百分数，支持 "50%" 形式的字符串
+func (v *Valuer) IsPercentage(options ...ErrorOption) *Valuer {
+	return v.simple("is_percentage", func(a any) bool {
+		f, _, ok := parsePercentage(a)
+		return ok && f >= 0 && f <= 100
+	}, merge(options, ErrorParam("min", 0), ErrorParam("max", 100)))
+}
+
+// IsRatio 值必须是 0 到 1 之间的比例，"50%" 形式的字符串将被视为 0.5
+func (v *Valuer) IsRatio(options ...ErrorOption) *Valuer {
+	return v.simple("is_percentage", func(a any) bool {
+		f, percent, ok := parsePercentage(a)
+		if percent {
+			f /= 100
+		}
+		return ok && f >= 0 && f <= 1
+	}, merge(options, ErrorParam("min", 0), ErrorParam("max", 1)))
+}
+
+// parsePercentage 解析百分数，第二个返回值表示是否带有百分号
+func parsePercentage(val any) (float64, bool, bool) {
+	if str, ok := val.(string); ok {
+		str = strings.TrimSpace(str)
+		if num, found := strings.CutSuffix(str, "%"); found {
+			f, ok := toFloat(num)
+			return f, true, ok
+		}
+	}
+	f, ok := toFloat(val)
+	return f, false, ok
+}
+
+// TimeOfDay 一天中的时刻
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// String 实现 fmt.Stringer 接口，格式为 15:04
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+}
+
+func (t TimeOfDay) minutes() int {
+	return t.Hour*60 + t.Minute
+}
+
+// IsBusinessDay 值必须是工作日（周一至周五），且不在节假日 holidays 中，
+// 值可以是 time.Time 或可以解析成时间的字符串；规则只检查值本身而不读取当前时间，
+// 因此不需要注入时钟，验证“当前是否为工作日”时将 time.Now() 作为值传入即可
+func (v *Valuer) IsBusinessDay(holidays []time.Time, options ...ErrorOption) *Valuer {
+	return v.simple("is_business_day", func(a any) bool {
+		t, ok := toTime(a)
+		if !ok {
+			return false
+		}
+		if wd := t.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			return false
+		}
+		y, m, d := t.Date()
+		for _, holiday := range holidays {
+			hy, hm, hd := holiday.In(t.Location()).Date()
+			if y == hy && m == hm && d == hd {
+				return false
+			}
+		}
+		return true
+	}, options)
+}
+
+// WithinBusinessHours 值必须在工作时间 [start, end) 之内，start 晚于 end 时表示跨越午夜的时段，
+// 如：22:00 至次日 06:00；与 IsBusinessDay 相同，规则只检查值本身而不读取当前时间
+func (v *Valuer) WithinBusinessHours(start, end TimeOfDay, options ...ErrorOption) *Valuer {
+	return v.simple("within_business_hours", func(a any) bool {
+		t, ok := toTime(a)
+		if !ok {
+			return false
+		}
+		m := t.Hour()*60 + t.Minute()
+		if start.minutes() > end.minutes() {
+			return m >= start.minutes() || m < end.minutes()
+		}
+		return m >= start.minutes() && m < end.minutes()
+	}, merge(options, ErrorParam("start", start), ErrorParam("end", end)))
+}
+
+// 将字符串解析成时间时依次尝试的格式
+var timeLayouts = []string{time.RFC3339, time.DateTime, time.DateOnly}
+
+// toTime 将 time.Time 或字符串转换成时间
+func toTime(val any) (time.Time, bool) {
+	if t, ok := val.(time.Time); ok {
+		return t, true
+	}
+	str, ok := val.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// HasKeys 值必须是包含全部指定键的字典
+func (v *Valuer) HasKeys(keys []string, options ...ErrorOption) *Valuer {
+	v.describe("has_keys", merge(options, ErrorParam("keys", strings.Join(keys, ", "))))
+	return v.addRule(func(val any) error {
+		present, ok := mapKeys(val)
+		var missing []string
+		for _, key := range keys {
+			if _, found := present[key]; !found {
+				missing = append(missing, key)
+			}
+		}
+		if ok && len(missing) == 0 {
+			return nil
+		}
+		return v.newError("has_keys", merge(options, ErrorParam("keys", strings.Join(missing, ", "))))
+	})
+}
+
+// OnlyKeys 值必须是字典，且只能包含指定的键
+func (v *Valuer) OnlyKeys(keys []string, options ...ErrorOption) *Valuer {
+	v.describe("only_keys", merge(options, ErrorParam("keys", strings.Join(keys, ", "))))
+	return v.addRule(func(val any) error {
+		present, ok := mapKeys(val)
+		for _, key := range keys {
+			delete(present, key)
+		}
+		if ok && len(present) == 0 {
+			return nil
+		}
+		extra := make([]string, 0, len(present))
+		for key := range present {
+			extra = append(extra, key)
+		}
+		sort.Strings(extra)
+		return v.newError("only_keys", merge(options, ErrorParam("keys", strings.Join(extra, ", "))))
+	})
+}
+
+// mapKeys 返回字典的键集合，值不是字典时第二个返回值为 false
+func mapKeys(val any) (map[string]struct{}, bool) {
+	rv := indirect(reflect.ValueOf(val))
+	if rv.Kind() != reflect.Map {
+		return map[string]struct{}{}, false
+	}
+	keys := make(map[string]struct{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		keys[toString(iter.Key().Interface())] = struct{}{}
+	}
+	return keys, true
+}
+
+// UniqueAcross 值在共享集合 seen 中必须是唯一的，验证通过时将值记录到 seen 中，
+// 用于在同一批次的多次验证中检查重复值。seen 可被多个协程并发使用，
+// 值一经记录便不会被移除（即使后续规则验证失败），需要重置时请使用新的 sync.Map
+func (v *Valuer) UniqueAcross(seen *sync.Map, options ...ErrorOption) *Valuer {
+	return v.simple("unique_across", func(a any) bool {
+		_, loaded := seen.LoadOrStore(hashKey(a), struct{}{})
+		return !loaded
+	}, options)
+}
+
+// UniqueByField 结构体切片中元素的指定字段（如：ID）的值不能重复，
+// 失败时报告重复的值以及两个重复元素的索引
+func (v *Valuer) UniqueByField(field string, options ...ErrorOption) *Valuer {
+	v.describe("unique_by_field", merge(options, ErrorParam("field", field)))
+	return v.addRule(func(val any) error {
+		rv := indirect(reflect.ValueOf(val))
+		if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+			return nil
+		}
+		seen := newKeyIndex(rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			item := indirect(rv.Index(i))
+			if item.Kind() != reflect.Struct {
+				continue
+			}
+			f := item.FieldByName(field)
+			if !f.IsValid() {
+				panic(fmt.Errorf("field %q not found in %s", field, item.Type()))
+			}
+			if !f.CanInterface() {
+				panic(fmt.Errorf("field %q of %s is unexported", field, item.Type()))
+			}
+			key := f.Interface()
+			if j, found := seen.lookup(key); found {
+				return v.newError("unique_by_field", merge(
+					options,
+					ErrorParam("field", field),
+					ErrorParam("key", key),
+					ErrorParam("index", j),
+					ErrorParam("duplicate", i),
+				))
+			}
+			seen.store(key, i)
+		}
+		return nil
+	})
+}
+
+// Distinct 切片或数组中的元素不能重复，如：标签列表，
+// 不可比较的元素（如：切片、字典）按其完整的值进行比较
+func (v *Valuer) Distinct(options ...ErrorOption) *Valuer {
+	return v.DistinctBy(nil, options...)
+}
+
+// DistinctBy 切片或数组中的元素通过 key 提取的键不能重复，key 为 nil 时使用元素本身，
+// 失败时通过参数 index 和 duplicate 报告重复的两个元素的索引
+func (v *Valuer) DistinctBy(key func(item any) any, options ...ErrorOption) *Valuer {
+	v.describe("distinct", options)
+	return v.addRule(func(val any) error {
+		items, ok := sliceItems(val)
+		if !ok {
+			return nil
+		}
+		seen := newKeyIndex(len(items))
+		for i, item := range items {
+			k := item
+			if key != nil {
+				k = key(item)
+			}
+			if j, found := seen.lookup(k); found {
+				return v.newError("distinct", merge(
+					options,
+					ErrorParam("key", k),
+					ErrorParam("index", j),
+					ErrorParam("duplicate", i),
+				))
+			}
+			seen.store(k, i)
+		}
+		return nil
+	})
+}
+
+// reprKey 不可比较的值的 Go 语法表示，使用独立的类型以免与相同内容的字符串冲突
+type reprKey string
+
+// hashKey 返回可以作为 map 键的值，不可比较的值（包括字段中含有切片等的结构体）使用其 Go 语法表示代替
+func hashKey(val any) any {
+	if !hashable(reflect.ValueOf(val)) {
+		return reprKey(fmt.Sprintf("%#v", val))
+	}
+	return val
+}
+
+// hashable 判断值是否可以作为 map 键，接口类型的字段按其实际值判断，避免 map 赋值时 panic
+func hashable(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Interface:
+		return rv.IsNil() || hashable(rv.Elem())
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			if !hashable(rv.Field(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if !hashable(rv.Index(i)) {
+				return false
+			}
+		}
+		return rv.Type().Comparable()
+	default:
+		return rv.Type().Comparable()
+	}
+}
+
+// keyIndex 记录键及其首次出现的索引，可以作为 map 键的值使用 map 查找，
+// 其它值（如：切片、字典）通过 reflect.DeepEqual 逐一比较
+type keyIndex struct {
+	hashed map[any]int
+	others []any
+	index  []int
+}
+
+func newKeyIndex(size int) *keyIndex {
+	return &keyIndex{hashed: make(map[any]int, size)}
+}
+
+// lookup 返回与 key 相等的键首次出现的索引
+func (k *keyIndex) lookup(key any) (int, bool) {
+	if hashable(reflect.ValueOf(key)) {
+		i, found := k.hashed[key]
+		return i, found
+	}
+	for n, other := range k.others {
+		if reflect.DeepEqual(other, key) {
+			return k.index[n], true
+		}
+	}
+	return 0, false
+}
+
+// store 记录键首次出现的索引
+func (k *keyIndex) store(key any, i int) {
+	if hashable(reflect.ValueOf(key)) {
+		k.hashed[key] = i
+		return
+	}
+	k.others = append(k.others, key)
+	k.index = append(k.index, i)
+}
+
+// StrictlyIncreasing 切片或数组的元素必须严格递增（相邻元素不能相等）
+func (v *Valuer) StrictlyIncreasing(options ...ErrorOption) *Valuer {
+	return v.monotonic("strictly_increasing", func(prev, next any) bool { return is.GreaterThan(next, prev) }, options)
+}
+
+// StrictlyDecreasing 切片或数组的元素必须严格递减（相邻元素不能相等）
+func (v *Valuer) StrictlyDecreasing(options ...ErrorOption) *Valuer {
+	return v.monotonic("strictly_decreasing", func(prev, next any) bool { return is.LessThan(next, prev) }, options)
+}
+
+// monotonic 检查切片或数组中的每对相邻元素，失败时报告这对元素的索引
+func (v *Valuer) monotonic(code string, ordered func(prev, next any) bool, options []ErrorOption) *Valuer {
+	v.describe(code, options)
+	return v.addRule(func(val any) error {
+		rv := indirect(reflect.ValueOf(val))
+		if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+			return v.newError(code, merge(options, ErrorParam("index", -1), ErrorParam("next", -1)))
+		}
+		for i := 1; i < rv.Len(); i++ {
+			if !ordered(rv.Index(i-1).Interface(), rv.Index(i).Interface()) {
+				return v.newError(code, merge(options, ErrorParam("index", i-1), ErrorParam("next", i)))
+			}
+		}
+		return nil
+	})
+}
+
+// NoNilItems 切片、数组或字典中不能包含 nil 元素，失败时报告第一个 nil 元素的索引或键
+func (v *Valuer) NoNilItems(options ...ErrorOption) *Valuer {
+	v.describe("no_nil_items", options)
+	return v.addRule(func(val any) error {
+		rv := indirect(reflect.ValueOf(val))
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
 			for i := 0; i < rv.Len(); i++ {
-				skip, err := check(&Item{
-					Key:   nil,
-					Index: i,
-					Value: rv.Index(i).Interface(),
-				})
-				if err != nil {
-					return v.mistake(err)
-				}
-				if skip {
-					break
+				if isNil(rv.Index(i)) {
+					return v.newError("no_nil_items", merge(options, ErrorParam("index", i)))
 				}
 			}
 		case reflect.Map:
-			iter := rv.MapRange()
-			for iter.Next() {
-				skip, err := check(&Item{
-					Key:   iter.Key().String(),
-					Value: iter.Value().Interface(),
-				})
-				if err != nil {
-					return v.mistake(err)
+			keys := rv.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return toString(keys[i].Interface()) < toString(keys[j].Interface()) })
+			for _, key := range keys {
+				if isNil(rv.MapIndex(key)) {
+					return v.newError("no_nil_items", merge(options, ErrorParam("index", key.Interface())))
 				}
-				if skip {
-					break
+			}
+		}
+		return nil
+	})
+}
+
+// Subset 切片或数组的每一个元素都必须是 allowed 中的一项，如：提交的权限必须在允许的范围内，
+// 失败时通过参数 offending 报告第一个不在 allowed 中的元素
+func (v *Valuer) Subset(allowed []any, options ...ErrorOption) *Valuer {
+	v.describe("subset", merge(options, ErrorParam("items", allowed)))
+	return v.addRule(func(val any) error {
+		items, ok := sliceItems(val)
+		if !ok {
+			return v.newError("subset", merge(options, ErrorParam("items", allowed), ErrorParam("offending", nil)))
+		}
+		for _, item := range items {
+			if !is.OneOf(item, allowed) {
+				return v.newError("subset", merge(options, ErrorParam("items", allowed), ErrorParam("offending", item)))
+			}
+		}
+		return nil
+	})
+}
+
+// Superset 切片或数组必须包含 required 中的每一项，
+// 失败时通过参数 offending 报告第一个缺失的项
+func (v *Valuer) Superset(required []any, options ...ErrorOption) *Valuer {
+	v.describe("superset", merge(options, ErrorParam("items", required)))
+	return v.addRule(func(val any) error {
+		items, ok := sliceItems(val)
+		if !ok {
+			return v.newError("superset", merge(options, ErrorParam("items", required), ErrorParam("offending", nil)))
+		}
+		for _, item := range required {
+			if !is.OneOf(item, items) {
+				return v.newError("superset", merge(options, ErrorParam("items", required), ErrorParam("offending", item)))
+			}
+		}
+		return nil
+	})
+}
+
+// sliceItems 返回切片或数组的元素，值不是切片或数组时返回 false
+func sliceItems(val any) ([]any, bool) {
+	rv := indirect(reflect.ValueOf(val))
+	if k := rv.Kind(); k != reflect.Slice && k != reflect.Array {
+		return nil, false
+	}
+	items := make([]any, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// isNil 判断反射值是否为 nil，不可为 nil 的类型返回 false
+func isNil(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice, reflect.UnsafePointer:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
+// 默认成对出现的括号
+var defaultDelimiters = map[rune]rune{'(': ')', '[': ']', '{': '}'}
+
+// BalancedDelimiters 值中的括号或引号必须成对出现且正确嵌套，
+// pairs 为开始符号到结束符号的映射（开始与结束符号相同时表示引号），
+// 为 nil 时使用圆括号、方括号和花括号，失败时报告第一个不匹配的字符位置
+func (v *Valuer) BalancedDelimiters(pairs map[rune]rune, options ...ErrorOption) *Valuer {
+	if pairs == nil {
+		pairs = defaultDelimiters
+	}
+	closers := make(map[rune]rune, len(pairs))
+	for open, close := range pairs {
+		closers[close] = open
+	}
+	v.describe("balanced_delimiters", options)
+	return v.addRule(func(val any) error {
+		type opening struct {
+			char     rune
+			position int
+		}
+		var stack []opening
+		position := 0
+		for _, c := range toString(val) {
+			top := len(stack) - 1
+			if close, ok := pairs[c]; ok && (close != c || top < 0 || stack[top].char != c) {
+				stack = append(stack, opening{c, position})
+			} else if open, ok := closers[c]; ok {
+				if top < 0 || stack[top].char != open {
+					return v.newError("balanced_delimiters", merge(options, ErrorParam("position", position)))
 				}
+				stack = stack[:top]
+			}
+			position++
+		}
+		if len(stack) > 0 {
+			return v.newError("balanced_delimiters", merge(options, ErrorParam("position", stack[len(stack)-1].position)))
+		}
+		return nil
+	})
+}
+
+// decimalPattern 可以带正负号的十进制数值字符串，如：-12.5、.5
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`)
+
+// NoLeadingZeros 数值字符串不能包含前导零，如："007"，但 "0" 和 "0.5" 是有效的，
+// 值不是十进制数值字符串时返回 numeric 错误
+func (v *Valuer) NoLeadingZeros(options ...ErrorOption) *Valuer {
+	v.describe("no_leading_zeros", options)
+	return v.addRule(func(val any) error {
+		s := toString(val)
+		if !decimalPattern.MatchString(s) {
+			return v.newError("numeric", options)
+		}
+		s = strings.TrimLeft(s, "+-")
+		if len(s) < 2 || s[0] != '0' || s[1] == '.' {
+			return nil
+		}
+		return v.newError("no_leading_zeros", options)
+	})
+}
+
+// MaxSignificantDigits 值的有效数字位数不能超过 max（与小数位数不同），
+// 前导零不计入有效数字，不含小数点的整数末尾的零也不计入
+func (v *Valuer) MaxSignificantDigits(max int, options ...ErrorOption) *Valuer {
+	v.describe("max_significant_digits", merge(options, ErrorParam("max", max)))
+	return v.addRule(func(val any) error {
+		digits, ok := significantDigits(val)
+		if ok && digits <= max {
+			return nil
+		}
+		return v.newError("max_significant_digits", merge(options, ErrorParam("max", max), ErrorParam("digits", digits)))
+	})
+}
+
+// significantDigits 计算数值或数值字符串的有效数字位数
+func significantDigits(val any) (int, bool) {
+	var str string
+	switch x := val.(type) {
+	case float32:
+		str = strconv.FormatFloat(float64(x), 'f', -1, 32)
+	case float64:
+		str = strconv.FormatFloat(x, 'f', -1, 64)
+	default:
+		str = strings.TrimSpace(toString(val))
+	}
+	if _, ok := toFloat(str); !ok {
+		return 0, false
+	}
+	str = strings.TrimLeft(str, "+-")
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		str = str[:i]
+	}
+	mantissa := strings.Replace(str, ".", "", 1)
+	mantissa = strings.TrimLeft(mantissa, "0")
+	if !strings.Contains(str, ".") {
+		mantissa = strings.TrimRight(mantissa, "0")
+	}
+	return len(mantissa), true
+}
+
+type Item struct {
+	Key   any
+	Index int
+	Value any
+}
+
+func (v *Valuer) itemize(handle func(item *Item) any, every bool, options []ErrorOption) *Valuer {
+	if every {
+		v.describe("every", options)
+	} else {
+		v.describe("some", options)
+	}
+	// check 验证单个元素，返回元素是否通过验证，未通过时同时返回元素的错误
+	check := func(ctx context.Context, item *Item) (bool, error) {
+		switch res := handle(item).(type) {
+		case nil:
+			return true, nil
+		case bool:
+			if res {
+				return true, nil
+			}
+			return false, v.newError("every", options)
+		case Validatable:
+			if err := validateContext(ctx, res); err != nil {
+				return false, err
+			}
+			return true, nil
+		case error:
+			// 自定义错误
+			return false, v.mistake(res, options...)
+		default:
+			panic(fmt.Errorf("expect a bool, a Validatable or a error, got %+v", res))
+		}
+	}
+
+	v.addContextRule(func(ctx context.Context, a any) error {
+		var failure error
+		// visit 验证单个元素，返回 true 时停止遍历：
+		// every 在第一个未通过验证的元素处停止，some 在第一个通过验证的元素处停止
+		visit := func(item *Item) bool {
+			ok, err := check(ctx, item)
+			if every && !ok {
+				failure = v.nestAt(v.itemPath(item), err)
+			}
+			return ok != every
+		}
+
+		stopped := false
+		rv := indirect(reflect.ValueOf(a))
+		switch k := rv.Kind(); k {
+		case reflect.Invalid, reflect.Ptr, reflect.Interface:
+			// nil 值视为空集合
+		case reflect.Array, reflect.Slice:
+			for i := 0; i < rv.Len() && !stopped; i++ {
+				stopped = visit(&Item{Index: i, Value: rv.Index(i).Interface()})
+			}
+		case reflect.Map:
+			iter := rv.MapRange()
+			for iter.Next() && !stopped {
+				stopped = visit(&Item{Key: iter.Key().Interface(), Value: iter.Value().Interface()})
 			}
 		case reflect.Struct:
-			for i := 0; i < rt.NumField(); i++ {
-				field := rt.Field(i)
-				skip, err := check(&Item{
-					Key:   field.Name,
-					Value: rv.FieldByName(field.Name).Interface(),
-				})
-				if err != nil {
-					return v.mistake(err)
-				}
-				if skip {
+			for i, name := range structFieldNames(rv.Type()) {
+				if stopped = visit(&Item{Key: name, Value: rv.Field(i).Interface()}); stopped {
 					break
 				}
 			}
@@ -680,15 +2527,43 @@ func (v *Valuer) itemize(handle func(item *Item) any, every bool, options []Erro
 		}
 
 		if every {
+			return failure
+		}
+		if stopped {
+			// some 成功
 			return nil
 		}
-
 		return v.newError("some", options)
 	})
 
 	return v
 }
 
+// structFields 缓存结构体类型的字段名，以 reflect.Type 为键
+var structFields sync.Map
+
+// structFieldNames 返回结构体类型按字段索引排列的字段名
+func structFieldNames(rt reflect.Type) []string {
+	if names, ok := structFields.Load(rt); ok {
+		return names.([]string)
+	}
+	names := make([]string, rt.NumField())
+	for i := range names {
+		names[i] = rt.Field(i).Name
+	}
+	structFields.Store(rt, names)
+	return names
+}
+
+// itemPath 返回集合元素的路径，切片和数组元素如：tags[3]，字典和结构体的元素如：address.city，
+// 设置了 SetPathSeparator 时字典和结构体的元素使用该分隔符
+func (v *Valuer) itemPath(item *Item) string {
+	if item.Key != nil {
+		return v.field + fieldSeparator() + toString(item.Key)
+	}
+	return v.field + "[" + strconv.Itoa(item.Index) + "]"
+}
+
 func (v *Valuer) Every(handle func(item *Item) any, options ...ErrorOption) *Valuer {
 	return v.itemize(handle, true, options)
 }
@@ -697,6 +2572,152 @@ func (v *Valuer) Some(handle func(item *Item) any, options ...ErrorOption) *Valu
 	return v.itemize(handle, false, options)
 }
 
+// EachKey 验证字典的每一个键，如：所有的键都必须是有效的标识符，
+// handle 的返回值与 Every 相同，切片和数组的键为元素的索引，结构体的键为字段名
+func (v *Valuer) EachKey(handle func(key any) any, options ...ErrorOption) *Valuer {
+	return v.itemize(func(item *Item) any {
+		if item.Key == nil {
+			return handle(item.Index)
+		}
+		return handle(item.Key)
+	}, true, options)
+}
+
+// EachValue 验证字典（或切片、数组、结构体）的每一个值，handle 的返回值与 Every 相同
+func (v *Valuer) EachValue(handle func(value any) any, options ...ErrorOption) *Valuer {
+	return v.itemize(func(item *Item) any {
+		return handle(item.Value)
+	}, true, options)
+}
+
+// isEmpty 判断值是否为空，对于切片、数组和字典（包括指向它们的指针），
+// 无论是否为 nil，只要长度为 0 即视为空，其它类型的值参考 is.Empty
+func isEmpty(value any) bool {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return true
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len() == 0
+	default:
+		return is.Empty(value)
+	}
+}
+
+// isSlug 判断字符串是否由连字符分隔的若干段小写字母和数字组成
+func isSlug(s string, allowUnicode bool) bool {
+	if s == "" {
+		return false
+	}
+	for _, part := range strings.Split(s, "-") {
+		if part == "" {
+			return false
+		}
+		for _, c := range part {
+			switch {
+			case 'a' <= c && c <= 'z', '0' <= c && c <= '9':
+			case allowUnicode && c > unicode.MaxASCII && (unicode.IsLetter(c) && !unicode.IsUpper(c) || unicode.IsDigit(c)):
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isEnvVarName(s string, lower bool) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		switch {
+		case c == '_', 'A' <= c && c <= 'Z':
+		case lower && 'a' <= c && c <= 'z':
+		case i > 0 && '0' <= c && c <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// indirect 解开指针和接口，直到遇到 nil 或非指针（接口）类型的值
+func indirect(rv reflect.Value) reflect.Value {
+	for (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	return rv
+}
+
+// 已编译的正则表达式缓存
+var regexps sync.Map
+
+// compileRegexp 编译并缓存正则表达式，表达式无效时触发 panic
+func compileRegexp(pattern string) *regexp.Regexp {
+	if re, ok := regexps.Load(pattern); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(pattern)
+	regexps.Store(pattern, re)
+	return re
+}
+
+// toFloat 将数值或数值字符串转换成 float64
+func toFloat(val any) (float64, bool) {
+	rv := reflect.Indirect(reflect.ValueOf(val))
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// isUUIDVersion 字符串是否为指定版本的标准格式（8-4-4-4-12）UUID，且变体为 RFC 4122
+func isUUIDVersion(s string, version int) bool {
+	if len(s) != 36 || version < 1 || version > 8 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	if int(s[14]-'0') != version {
+		return false
+	}
+	return strings.ContainsRune("89abAB", rune(s[19]))
+}
+
+// sign 返回数值的符号：1 为正数，-1 为负数，0 为零
+func sign(f float64) int {
+	if f > 0 {
+		return 1
+	} else if f < 0 {
+		return -1
+	}
+	return 0
+}
+
 func toString(val any) string {
 	if str, ok := val.(string); ok {
 		return str
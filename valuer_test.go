@@ -0,0 +1,689 @@
+package v
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// codeOf 返回验证结果中第一个错误的错误代码，验证通过时返回空字符串
+func codeOf(t *testing.T, err error) string {
+	t.Helper()
+	switch x := err.(type) {
+	case nil:
+		return ""
+	case *Error:
+		return x.Code()
+	case *Errors:
+		if x.IsEmpty() {
+			t.Fatalf("expect a non-empty *Errors, got %#v", x)
+		}
+		return x.First().Code()
+	default:
+		t.Fatalf("expect *Error or *Errors, got %T: %v", err, err)
+		return ""
+	}
+}
+
+func TestRequiredCollections(t *testing.T) {
+	var nilSlice []string
+	var nilMap map[string]int
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"nil slice", nilSlice, "required"},
+		{"empty slice", []string{}, "required"},
+		{"populated slice", []string{"a"}, ""},
+		{"nil map", nilMap, "required"},
+		{"empty map", map[string]int{}, "required"},
+		{"populated map", map[string]int{"a": 1}, ""},
+		{"pointer to empty slice", &[]int{}, "required"},
+		{"nil pointer to slice", (*[]int)(nil), "required"},
+		{"pointer to populated slice", &[]int{1}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Value(tt.value, "tags", "标签").Required().Validate()
+			if got := codeOf(t, err); got != tt.want {
+				t.Errorf("Required(%#v) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomWithParams(t *testing.T) {
+	check := func(val any, set func(key string, value any)) any {
+		set("min", 3)
+		set("actual", len(val.(string)))
+		return false
+	}
+	err := Value("ab", "name", "名称").CustomWith("too_short", check).Validate().(*Error)
+	if got := err.Params()["actual"]; got != 2 {
+		t.Errorf("params[actual] = %v, want 2", got)
+	}
+	if got := err.Params()["min"]; got != 3 {
+		t.Errorf("params[min] = %v, want 3", got)
+	}
+
+	// 显式传入的错误参数优先于回调函数设置的参数
+	err = Value("ab", "name", "名称").CustomWith("too_short", check, ErrorParam("min", 5)).Validate().(*Error)
+	if got := err.Params()["min"]; got != 5 {
+		t.Errorf("params[min] = %v, want 5", got)
+	}
+}
+
+func TestLastRunSkipped(t *testing.T) {
+	v := Value("", "nickname", "昵称").Custom("never", func(any) any { return false })
+	if v.LastRunSkipped() {
+		t.Fatal("LastRunSkipped before Validate = true, want false")
+	}
+	if err := v.Validate(); err != nil {
+		t.Fatalf("Validate empty value = %v, want nil", err)
+	}
+	if !v.LastRunSkipped() {
+		t.Error("LastRunSkipped after empty value = false, want true")
+	}
+
+	v.value = "bob"
+	if code := codeOf(t, v.Validate()); code != "never" {
+		t.Errorf("Validate non-empty value = %q, want never", code)
+	}
+	if v.LastRunSkipped() {
+		t.Error("LastRunSkipped after non-empty value = true, want false")
+	}
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	holiday := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{time.Date(2024, 9, 30, 10, 0, 0, 0, time.UTC), ""},                // 周一
+		{time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC), "is_business_day"}, // 节假日
+		{time.Date(2024, 10, 5, 10, 0, 0, 0, time.UTC), "is_business_day"}, // 周六
+		{"2024-10-08", ""},
+		{"2024-10-06 09:00:00", "is_business_day"},
+		{"not a date", "is_business_day"},
+	}
+	for _, tt := range tests {
+		err := Value(tt.value, "date", "日期").IsBusinessDay([]time.Time{holiday}).Validate()
+		if got := codeOf(t, err); got != tt.want {
+			t.Errorf("IsBusinessDay(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWithinBusinessHours(t *testing.T) {
+	day, night := [2]TimeOfDay{{Hour: 9}, {Hour: 18}}, [2]TimeOfDay{{Hour: 22}, {Hour: 6}}
+	tests := []struct {
+		hours [2]TimeOfDay
+		value any
+		want  string
+	}{
+		{day, "2024-10-08 09:00:00", ""},
+		{day, "2024-10-08 17:59:00", ""},
+		{day, "2024-10-08 18:00:00", "within_business_hours"},
+		{day, "2024-10-08 08:59:00", "within_business_hours"},
+		{night, "2024-10-08 23:00:00", ""},
+		{night, "2024-10-08 22:00:00", ""},
+		{night, "2024-10-08 05:59:00", ""},
+		{night, "2024-10-08 06:00:00", "within_business_hours"},
+		{night, "2024-10-08 12:00:00", "within_business_hours"},
+	}
+	for _, tt := range tests {
+		err := Value(tt.value, "time", "时间").WithinBusinessHours(tt.hours[0], tt.hours[1]).Validate()
+		if got := codeOf(t, err); got != tt.want {
+			t.Errorf("WithinBusinessHours(%v, %v)(%v) = %q, want %q", tt.hours[0], tt.hours[1], tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRuleTimeout(t *testing.T) {
+	slow := func(ctx context.Context, val any) any {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+		return true
+	}
+	fast := func(ctx context.Context, val any) any { return val == "ok" }
+
+	start := time.Now()
+	err := Value("ok", "name", "名称").CustomContext("slow", slow).RuleTimeout(20 * time.Millisecond).Validate()
+	if code := codeOf(t, err); code != "timeout" {
+		t.Errorf("slow rule = %q, want timeout", code)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("slow rule took %v, want it to stop at the timeout", elapsed)
+	}
+
+	if err := Value("ok", "name", "名称").CustomContext("fast", fast).RuleTimeout(time.Second).Validate(); err != nil {
+		t.Errorf("fast rule = %v, want nil", err)
+	}
+	if code := codeOf(t, Value("bad", "name", "名称").CustomContext("fast", fast).RuleTimeout(time.Second).Validate()); code != "fast" {
+		t.Errorf("failing fast rule = %q, want fast", code)
+	}
+
+	// 父级上下文被取消时返回上下文的错误而不是 timeout 错误
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = Value("ok", "name", "名称").CustomContext("slow", slow).RuleTimeout(time.Second).ValidateContext(ctx)
+	if err != context.DeadlineExceeded {
+		t.Errorf("cancelled parent = %v, want context.DeadlineExceeded", err)
+	}
+
+	// Timeout 限制 build 添加的全部规则
+	err = Value("ok", "name", "名称").Timeout(20*time.Millisecond, func(x *Valuer) {
+		x.CustomContext("fast", fast).CustomContext("slow", slow)
+	}).Validate()
+	if code := codeOf(t, err); code != "timeout" {
+		t.Errorf("Timeout block = %q, want timeout", code)
+	}
+}
+
+func TestRuleTimeoutPanic(t *testing.T) {
+	defer func() {
+		r := recover()
+		p, ok := r.(*rulePanic)
+		if !ok {
+			t.Fatalf("recovered %T %v, want *rulePanic", r, r)
+		}
+		if p.value != "boom" || !strings.Contains(p.Error(), "goroutine stack") {
+			t.Errorf("panic = %q, want boom with the goroutine stack", p.Error())
+		}
+	}()
+	Value("ok", "name", "名称").Custom("boom", func(any) any { panic("boom") }).RuleTimeout(time.Second).Validate()
+}
+
+func TestRuleTimeoutWithoutRule(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RuleTimeout without rules did not panic")
+		}
+	}()
+	Value("ok", "name", "名称").Required().RuleTimeout(time.Second)
+}
+
+type testColor int
+
+func (testColor) Values() []testColor { return []testColor{1, 2} }
+
+func TestInEnum(t *testing.T) {
+	if err := Value(testColor(1), "color", "颜色").InEnum(testColor(0)).Validate(); err != nil {
+		t.Errorf("InEnum(valid) = %v, want nil", err)
+	}
+	if got := codeOf(t, Value(testColor(3), "color", "颜色").InEnum(testColor(0)).Validate()); got != "one_of" {
+		t.Errorf("InEnum(invalid) code = %q, want one_of", got)
+	}
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !strings.Contains(err.Error(), "must not be nil") {
+			t.Errorf("InEnum(nil) panic = %v, want a nil enum error", r)
+		}
+	}()
+	Value(1, "color", "颜色").InEnum(nil)
+}
+
+func TestUniqueByField(t *testing.T) {
+	type user struct {
+		ID   int
+		name string
+	}
+	unique := []user{{ID: 1}, {ID: 2}, {ID: 3}}
+	if err := Value(unique, "users", "用户").UniqueByField("ID").Validate(); err != nil {
+		t.Errorf("UniqueByField(unique) = %v, want nil", err)
+	}
+
+	err := Value([]*user{{ID: 1}, {ID: 2}, {ID: 1}}, "users", "用户").UniqueByField("ID").Validate()
+	e, ok := err.(*Error)
+	if !ok || e.Code() != "unique_by_field" {
+		t.Fatalf("UniqueByField(duplicate) = %#v, want a unique_by_field error", err)
+	}
+	if p := e.Params(); p["index"] != 0 || p["duplicate"] != 2 || p["key"] != 1 {
+		t.Errorf("UniqueByField(duplicate) params = %v, want index 0, duplicate 2, key 1", p)
+	}
+
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		if !ok || !strings.Contains(err.Error(), "unexported") {
+			t.Errorf("UniqueByField(unexported) panic = %v, want an unexported field error", r)
+		}
+	}()
+	_ = Value(unique, "users", "用户").UniqueByField("name").Validate()
+}
+
+func TestOnError(t *testing.T) {
+	var calls []string
+	err := Value("", "name", "姓名").
+		Required().
+		OnError(func(e *Error) *Error {
+			calls = append(calls, "first:"+e.Code())
+			ErrorParam("hint", "bob")(e)
+			return e
+		}).
+		OnError(func(e *Error) *Error {
+			calls = append(calls, "second:"+e.Code())
+			return e
+		}).
+		Validate()
+	if got := strings.Join(calls, ","); got != "first:required,second:required" {
+		t.Errorf("hooks called as %q, want first:required,second:required", got)
+	}
+	if e, ok := err.(*Error); !ok || e.Params()["hint"] != "bob" {
+		t.Errorf("Validate() = %#v, want the error modified by the hook", err)
+	}
+
+	calls = nil
+	err = Value("", "name", "姓名").
+		Required().
+		OnError(func(e *Error) *Error {
+			calls = append(calls, "suppress")
+			return nil
+		}).
+		OnError(func(e *Error) *Error {
+			calls = append(calls, "unreachable")
+			return e
+		}).
+		Validate()
+	if err != nil {
+		t.Errorf("Validate() = %v, want nil after the hook suppressed the error", err)
+	}
+	if got := strings.Join(calls, ","); got != "suppress" {
+		t.Errorf("hooks called as %q, want only suppress", got)
+	}
+
+	if err := Value("bob", "name", "姓名").Required().OnError(func(e *Error) *Error {
+		t.Error("hook called for a valid value")
+		return e
+	}).Validate(); err != nil {
+		t.Errorf("Validate(valid) = %v, want nil", err)
+	}
+}
+
+func TestPathSeparator(t *testing.T) {
+	nested := func() error {
+		return Value(map[string]any{"home": ""}, "addresses", "地址").Every(func(item *Item) any {
+			return Value(item.Value, "city", "城市").Required()
+		}).Validate()
+	}
+	fieldOf := func(err error) string {
+		errs := Join(err).All()
+		if len(errs) != 1 {
+			t.Fatalf("got %d errors, want 1: %v", len(errs), err)
+		}
+		return errs[0].Field()
+	}
+
+	if got := fieldOf(nested()); got != "addresses.home.city" {
+		t.Errorf("default field = %q, want addresses.home.city", got)
+	}
+
+	SetPathSeparator("/")
+	defer SetPathSeparator("")
+	if got := fieldOf(nested()); got != "addresses/home/city" {
+		t.Errorf("field with separator = %q, want addresses/home/city", got)
+	}
+
+	// SetPathSeparator 可以与验证并发执行
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetPathSeparator("/")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_ = nested()
+	}
+	<-done
+}
+
+type sharedError struct{ err *Error }
+
+func (s sharedError) Validate() error { return s.err }
+
+func TestNestAtDoesNotMutate(t *testing.T) {
+	shared := NewFieldError("city", "城市", "required")
+	for _, field := range []string{"home", "work"} {
+		err := Value(sharedError{shared}, field, "地址").Recurse().Validate()
+		if got, want := Join(err).All()[0].Field(), field+".city"; got != want {
+			t.Errorf("nested field = %q, want %q", got, want)
+		}
+	}
+	if shared.Field() != "city" {
+		t.Errorf("shared error field = %q, want city", shared.Field())
+	}
+}
+
+func TestSubTransformIsolated(t *testing.T) {
+	suffix := func(s string) func(any) any {
+		return func(a any) any { return a.(string) + s }
+	}
+	v := Value("x", "name", "姓名").Transform(suffix("1")).Transform(suffix("2")).Transform(suffix("3"))
+	a := v.sub("a").Transform(suffix("a"))
+	b := v.sub("b").Transform(suffix("b"))
+
+	run := func(x *Valuer) string {
+		var got string
+		x.Custom("capture", func(a any) any { got = a.(string); return true })
+		_ = x.Validate()
+		return got
+	}
+	if got := run(a); got != "x123a" {
+		t.Errorf("sub a value = %q, want x123a", got)
+	}
+	if got := run(b); got != "x123b" {
+		t.Errorf("sub b value = %q, want x123b", got)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	type tagged struct {
+		Name string
+		Tags any
+	}
+	tests := []struct {
+		name  string
+		items any
+		dup   int
+	}{
+		{"strings", []string{"a", "b", "c"}, -1},
+		{"duplicate strings", []string{"a", "b", "a"}, 2},
+		{"slices", [][]int{{1}, {2}, {1, 2}}, -1},
+		{"duplicate slices", [][]int{{1}, {2}, {1}}, 2},
+		{"string and slice", []any{"[]int{1}", []int{1}}, -1},
+		{"interface fields", []tagged{{"a", []int{1}}, {"a", []int{2}}}, -1},
+		{"duplicate interface fields", []tagged{{"a", []int{1}}, {"b", 1}, {"a", []int{1}}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Value(tt.items, "items", "列表").Distinct().Validate()
+			if tt.dup < 0 {
+				if err != nil {
+					t.Errorf("Distinct() = %v, want nil", err)
+				}
+				return
+			}
+			e, ok := err.(*Error)
+			if !ok || e.Code() != "distinct" || e.Params()["duplicate"] != tt.dup {
+				t.Errorf("Distinct() = %#v, want a distinct error with duplicate %d", err, tt.dup)
+			}
+		})
+	}
+}
+
+func TestUniqueAcross(t *testing.T) {
+	var seen sync.Map
+	for i, value := range []any{"[]int{1}", []int{1}, struct{ V any }{[]int{1}}} {
+		if err := Value(value, "value", "值").UniqueAcross(&seen).Validate(); err != nil {
+			t.Errorf("UniqueAcross(#%d) = %v, want nil", i, err)
+		}
+	}
+	if got := codeOf(t, Value([]int{1}, "value", "值").UniqueAcross(&seen).Validate()); got != "unique_across" {
+		t.Errorf("UniqueAcross(duplicate) code = %q, want unique_across", got)
+	}
+}
+
+type ctxKey struct{}
+
+// ctxRecorder 记录验证时收到的上下文中的值
+type ctxRecorder struct{ got *any }
+
+func (r ctxRecorder) Validate() error { return r.ValidateContext(context.Background()) }
+
+func (r ctxRecorder) ValidateContext(ctx context.Context) error {
+	*r.got = ctx.Value(ctxKey{})
+	return nil
+}
+
+func TestNestedValidatableContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	tests := []struct {
+		name  string
+		build func(v *Valuer, r ctxRecorder)
+	}{
+		{"Custom", func(v *Valuer, r ctxRecorder) { v.Custom("custom", func(any) any { return r }) }},
+		{"CustomContext", func(v *Valuer, r ctxRecorder) {
+			v.CustomContext("custom", func(context.Context, any) any { return r })
+		}},
+		{"Every", func(v *Valuer, r ctxRecorder) { v.Every(func(*Item) any { return r }) }},
+		{"Some", func(v *Valuer, r ctxRecorder) { v.Some(func(*Item) any { return r }) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got any
+			v := Value([]int{1}, "ids", "编号")
+			tt.build(v, ctxRecorder{&got})
+			if err := v.ValidateContext(ctx); err != nil {
+				t.Fatalf("ValidateContext() = %v, want nil", err)
+			}
+			if got != "request" {
+				t.Errorf("nested validator got context value %v, want request", got)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	v := Value("", "name", "姓名").
+		Required().
+		MinBytes(2).
+		MaxBytes(8).
+		Typeof(reflect.String).
+		HasKeys([]string{"a", "b"}).
+		Distinct(ErrorCode("unique_tags")).
+		When(true, func(x *Valuer) { x.MultipleOf(2) }).
+		When(false, func(x *Valuer) { x.IPVersion(4) }).
+		Unless(false, func(x *Valuer) { x.CountBetween(1, 3) })
+
+	var codes []string
+	for _, info := range v.Describe() {
+		codes = append(codes, info.Code)
+	}
+	want := "required,min_bytes,max_bytes,typeof,has_keys,unique_tags,when,unless"
+	if got := strings.Join(codes, ","); got != want {
+		t.Errorf("Describe() codes = %s, want %s", got, want)
+	}
+	if got := v.Describe()[4].Params["keys"]; got != "a, b" {
+		t.Errorf("has_keys keys = %v, want a, b", got)
+	}
+
+	item := func(*Item) any { return true }
+	builtins := Value("", "value", "值").
+		RequiredIf(true).
+		RequiredWith([]any{1}).
+		RequiredUnless(false).
+		RequiredWithout([]any{nil}).
+		RequiredIfMatches("a", "^a$").
+		SameSignAs(1).
+		MaxSignificantDigits(3).
+		IsSemverOf(NoPrerelease).
+		EqualFunc(func(val any) any { return val }).
+		LengthFunc(func(any) (int, int) { return 1, 2 }).
+		OneOfFunc(func() ([]any, bool) { return nil, false }).
+		Immutable(1).
+		Changed(1).
+		UniqueByField("ID").
+		Subset([]any{1}).
+		Superset([]any{1}).
+		NoNilItems().
+		StrictlyIncreasing().
+		StrictlyDecreasing().
+		BalancedDelimiters(nil).
+		MatchesJSONSchema("name").
+		Not(func(*Valuer) {}).
+		WhenValue(func(any) bool { return true }, func(*Valuer) {}).
+		UnlessValue(func(any) bool { return true }, func(*Valuer) {}).
+		Every(item).
+		Some(item).
+		EachKey(func(any) any { return true }).
+		EachValue(func(any) any { return true }).
+		Recurse()
+	codes = codes[:0]
+	for _, info := range builtins.Describe() {
+		codes = append(codes, info.Code)
+	}
+	want = "required_if,required_with,required_unless,required_without,required_if_matches," +
+		"same_sign,max_significant_digits,is_semver,equal,length_between,one_of,immutable,changed," +
+		"unique_by_field,subset,superset,no_nil_items,strictly_increasing,strictly_decreasing," +
+		"balanced_delimiters,json_schema,not,when,unless,every,some,every,every,recurse"
+	if got := strings.Join(codes, ","); got != want {
+		t.Errorf("Describe() codes = %s, want %s", got, want)
+	}
+	if got := builtins.Describe()[0].Params; len(got) != 0 {
+		t.Errorf("required_if params = %v, want none", got)
+	}
+
+	// 嵌套的构建函数只在验证时执行，空值跳过验证时不会执行
+	calls := 0
+	w := Value("", "cidr", "网段").When(true, func(x *Valuer) { calls++ })
+	_ = w.Describe()
+	if err := w.Validate(); err != nil || calls != 0 {
+		t.Errorf("When builder called %d times for an empty value (err %v), want 0", calls, err)
+	}
+}
+
+// BenchmarkItemizeWideStruct 遍历由 50 个字段的结构体组成的大切片，并逐一验证每个结构体的字段
+func BenchmarkItemizeWideStruct(b *testing.B) {
+	fields := make([]reflect.StructField, 50)
+	for i := range fields {
+		fields[i] = reflect.StructField{Name: "F" + strconv.Itoa(i), Type: reflect.TypeOf(0)}
+	}
+	rv := reflect.MakeSlice(reflect.SliceOf(reflect.StructOf(fields)), 1000, 1000)
+	for i := 0; i < rv.Len(); i++ {
+		// 零值结构体被视为空值而跳过验证
+		rv.Index(i).Field(0).SetInt(int64(i + 1))
+	}
+	items := rv.Interface()
+	every := Value(items, "items", "列表").Every(func(item *Item) any {
+		return Value(item.Value, "", "").Every(func(field *Item) any { return true })
+	})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := every.Validate(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestItemize(t *testing.T) {
+	positive := func(item *Item) any { return item.Value.(int) > 0 }
+	required := func(item *Item) any { return Value(item.Value, "name", "名称").Required() }
+	tests := []struct {
+		name   string
+		value  any
+		handle func(*Item) any
+		every  string // Every 返回的错误代码，通过时为空
+		path   string // Every 返回的错误的路径
+		some   string // Some 返回的错误代码，通过时为空
+	}{
+		{"bool all pass", []int{1, 2}, positive, "", "", ""},
+		{"bool one fails", []int{1, -1}, positive, "every", "ids[1]", ""},
+		{"bool all fail", []int{-1, -2}, positive, "every", "ids[0]", "some"},
+		{"bool map", map[string]int{"a": 1, "b": -1}, positive, "every", "ids.b", ""},
+		{"bool pointer", &[]int{1, -2}, positive, "every", "ids[1]", ""},
+		{"validatable all pass", []int{1, 2}, required, "", "", ""},
+		{"validatable one fails", []int{1, 0, 0}, required, "required", "ids[1].name", ""},
+		{"validatable all fail", []int{0, 0}, required, "required", "ids[0].name", "some"},
+		{"validatable map", map[string]int{"a": 0}, required, "required", "ids.a.name", "some"},
+		// 空集合（包括 nil 及指向 nil 的指针）视为空值，不会执行规则
+		{"empty", []int{}, positive, "", "", ""},
+		{"nil", []int(nil), required, "", "", ""},
+		{"nil map", map[string]int(nil), required, "", "", ""},
+		{"nil pointer", (*[]int)(nil), positive, "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Value(tt.value, "ids", "编号").Every(tt.handle).Validate()
+			if got := codeOf(t, err); got != tt.every {
+				t.Errorf("Every() code = %q, want %q", got, tt.every)
+			}
+			if errs := Join(err).All(); tt.path != "" && (len(errs) != 1 || errs[0].Path() != tt.path) {
+				t.Errorf("Every() = %v, want a single error at %s", err, tt.path)
+			}
+			err = Value(tt.value, "ids", "编号").Some(tt.handle).Validate()
+			if got := codeOf(t, err); got != tt.some {
+				t.Errorf("Some() code = %q, want %q", got, tt.some)
+			}
+		})
+	}
+}
+
+func TestEqualFieldParams(t *testing.T) {
+	err := Value("hunter2", "confirm", "确认密码").EqualField("s3cret", "password").Validate()
+	e, ok := err.(*Error)
+	if !ok || e.Code() != "equal_field" {
+		t.Fatalf("EqualField() = %#v, want an equal_field error", err)
+	}
+	params := e.Params()
+	if _, found := params["another"]; found {
+		t.Errorf("params = %v, want no value of the other field", params)
+	}
+	if params["other_field"] != "password" {
+		t.Errorf("params other_field = %v, want password", params["other_field"])
+	}
+}
+
+func TestMatchTransformed(t *testing.T) {
+	var branch string
+	err := Value("  a  ", "kind", "类型").
+		TrimSpace().
+		RedactValue().
+		Match(func(m *Matcher) {
+			m.Branch("a", func(x *Valuer) error {
+				branch = "a"
+				return x.Equal("b").Validate()
+			}).Fallback(func(x *Valuer) error {
+				branch = "fallback"
+				return nil
+			})
+		}).
+		Validate()
+	if branch != "a" {
+		t.Fatalf("matched branch %q, want a", branch)
+	}
+	e, ok := err.(*Error)
+	if !ok || e.Code() != "equal" {
+		t.Fatalf("Validate() = %#v, want an equal error from the branch", err)
+	}
+	if !e.Redacted() || e.Value() != "***" {
+		t.Errorf("branch error value = %v, want it redacted", e.Value())
+	}
+}
+
+func TestNoLeadingZeros(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{"0", ""},
+		{"0.5", ""},
+		{"-0", ""},
+		{"+12", ""},
+		{".5", ""},
+		{120, ""},
+		{"007", "no_leading_zeros"},
+		{"-01.5", "no_leading_zeros"},
+		{"abc", "numeric"},
+		{"+", "numeric"},
+		{"1.2.3", "numeric"},
+	}
+	for _, tt := range tests {
+		err := Value(tt.value, "code", "编号").NoLeadingZeros().Validate()
+		if got := codeOf(t, err); got != tt.want {
+			t.Errorf("NoLeadingZeros(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}